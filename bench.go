@@ -0,0 +1,49 @@
+package grace
+
+import (
+	"context"
+	"time"
+)
+
+// BenchResult is timing of runs by Benchmark.
+type BenchResult struct {
+	// Iterations is the number of runs.
+	Iterations int
+	// Errors is the number of runs failed.
+	Errors int
+	// Min is the shortest duration of a run.
+	Min time.Duration
+	// Max is the longest duration of a run.
+	Max time.Duration
+	// Mean is the average duration of a run.
+	Mean time.Duration
+	// Total is the sum of durations of runs.
+	Total time.Duration
+	// Steps is timing of every each task across runs, as same as Stats.
+	Steps []StepStats
+}
+
+// Benchmark runs given Task sequentially for the number of iterations, then returns timing of runs and their tasks.
+// As Task is immutable, the same chain is run on every each iteration. Runs failed are counted, yet timed as well.
+func Benchmark(t Task, iterations int) BenchResult {
+	stats := &Stats{}
+	r := NewRunner(WithStats(stats))
+	result := BenchResult{}
+	for ; result.Iterations < iterations; result.Iterations++ {
+		start := time.Now()
+		if err := r.Run(context.Background(), t); err != nil {
+			result.Errors++
+		}
+		d := time.Since(start)
+		if result.Iterations == 0 {
+			result.Min = d
+		}
+		result.Min, result.Max = min(result.Min, d), max(result.Max, d)
+		result.Total += d
+	}
+	if result.Iterations > 0 {
+		result.Mean = result.Total / time.Duration(result.Iterations)
+	}
+	result.Steps = stats.Snapshot().Steps
+	return result
+}
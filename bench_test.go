@@ -0,0 +1,41 @@
+package grace
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBenchmark_MustAggregateRuns(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	tsk := Named("sleep", WithNoErr(func() { time.Sleep(time.Millisecond * 2) })).
+		Then(With(func() error {
+			if calls++; calls%2 == 0 {
+				return errors.New("failure")
+			}
+			return nil
+		}))
+
+	result := Benchmark(tsk, 4)
+	assert.Equal(t, 4, result.Iterations)
+	assert.Equal(t, 2, result.Errors)
+	assert.GreaterOrEqual(t, result.Min, time.Millisecond*2)
+	assert.LessOrEqual(t, result.Min, result.Mean)
+	assert.LessOrEqual(t, result.Mean, result.Max)
+	assert.Equal(t, result.Total/4, result.Mean)
+	if assert.Len(t, result.Steps, 2) {
+		assert.Equal(t, "sleep", result.Steps[0].Name)
+		assert.Equal(t, 4, result.Steps[0].Count)
+		assert.GreaterOrEqual(t, result.Steps[0].Mean, time.Millisecond*2)
+		assert.Equal(t, 2, result.Steps[1].Errors)
+	}
+	assert.Equal(t, 2, Count(tsk), "must not affect the chain")
+}
+
+func TestBenchmark_MustReturnZero_WhenNoIterations(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, BenchResult{Steps: []StepStats{}}, Benchmark(With(nil), 0))
+	assert.Equal(t, 0, Benchmark(With(nil), -1).Iterations)
+}
@@ -0,0 +1,66 @@
+package grace
+
+// Sequence returns a chain of given tasks in order, built by Builder. Nil tasks are ignored.
+func Sequence(tasks ...Task) Task {
+	b := &Builder{}
+	for _, t := range tasks {
+		b.Append(t)
+	}
+	return b.Task()
+}
+
+// FromFuncs returns a chain of given functions in order, as every each is given to With. Nil functions are no-op tasks,
+// so that indices of tasks in the chain, as told by StepError, match those of the functions.
+func FromFuncs(fns ...func() error) Task {
+	b := &Builder{}
+	for _, fn := range fns {
+		b.Append(With(fn))
+	}
+	return b.Task()
+}
+
+// Builder builds a chain by appending tasks to its end. Unlike Then, which defers copying the chain to its traversal,
+// the chain is copied once when built, hence traversing it allocates nothing afterwards.
+// The zero value is ready to use.
+type Builder struct {
+	nodes []Task
+}
+
+// Add appends a task of given step, configured by options in order.
+func (b *Builder) Add(step Step, opts ...StepOption) *Builder {
+	node := With(step).(*task)
+	for _, opt := range opts {
+		if opt != nil {
+			node = opt(node)
+		}
+	}
+	return b.Append(node)
+}
+
+// Append appends every each task of given chain in order.
+func (b *Builder) Append(t Task) *Builder {
+	for ; !isNil(t); t = t.Next() {
+		b.nodes = append(b.nodes, t)
+	}
+	return b
+}
+
+// Task returns the chain of tasks appended thus far, or a no-op Task if nothing is appended.
+// The Builder can be used further, without affecting Task instances returned from it.
+func (b *Builder) Task() Task {
+	if len(b.nodes) == 0 {
+		return With(nil)
+	}
+	var next Task
+	for i := len(b.nodes) - 1; i >= 0; i-- {
+		node, ok := b.nodes[i].(*task)
+		if ok {
+			node = node.clone()
+		} else {
+			node = &task{step: b.nodes[i].Step()}
+		}
+		node.next, node.pending = next, nil
+		next = node
+	}
+	return next
+}
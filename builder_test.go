@@ -0,0 +1,159 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBuilder_MustBuildInOrder(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	b := &Builder{}
+	b.Add(store(valueOf(1))).Append(With(store(valueOf(2))).Then(With(store(valueOf(3))))).Add(store(valueOf(4)))
+
+	tsk := b.Task()
+	assert.Equal(t, 4, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1, 2, 3, 4}, sink.Values())
+}
+
+func TestBuilder_MustNotAffectBuiltTask(t *testing.T) {
+	t.Parallel()
+	appended := With(nil).Then(With(nil))
+	b := (&Builder{}).Append(appended)
+	first := b.Task()
+	b.Add(nil)
+	second := b.Task()
+
+	assert.Equal(t, 2, Count(first))
+	assert.Equal(t, 3, Count(second))
+	assert.Equal(t, 2, Count(appended))
+}
+
+func TestBuilder_MustKeepNames(t *testing.T) {
+	t.Parallel()
+	tsk := (&Builder{}).Append(Named("a", With(nil))).Add(nil).Task()
+	assert.Equal(t, "a -> #1 (2 steps)", Describe(tsk))
+}
+
+func TestBuilder_MustReturnNoOp_WhenEmpty(t *testing.T) {
+	t.Parallel()
+	tsk := (&Builder{}).Append(nil).Task()
+	assert.Equal(t, 1, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+}
+
+func TestBuilder_MustBuildLongChain(t *testing.T) {
+	t.Parallel()
+	b := &Builder{}
+	for i := 0; i < 100_000; i++ {
+		b.Add(nil)
+	}
+	tsk := b.Task()
+	assert.Equal(t, 100_000, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+}
+
+func TestSequence_MustChainInOrder(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	tsk := Sequence(With(store(valueOf(1))), nil, With(store(valueOf(2))).Then(With(store(valueOf(3)))))
+
+	assert.Equal(t, 3, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1, 2, 3}, sink.Values())
+	assert.Equal(t, 1, Count(Sequence()))
+}
+
+func TestFromFuncs_MustChainInOrder(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	tsk := FromFuncs(store(valueOf(1)), nil, store(valueOf(2)), store(valueOf(3)))
+
+	assert.Equal(t, 4, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1, 2, 3}, sink.Values())
+	assert.Equal(t, 1, Count(FromFuncs()))
+}
+
+func TestFromFuncs_MustAlignIndices_WithNils(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := FromFuncs(nil, func() error { return failure }, func() error { return nil })
+
+	report, err := tsk.RunReport(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+	assert.Equal(t, StepNotReached, report.Steps[2].Status)
+}
+
+func TestSequence_MustBuildAndRunLongChain(t *testing.T) {
+	t.Parallel()
+	tasks := make([]Task, 100_000)
+	for i := range tasks {
+		tasks[i] = With(nil)
+	}
+	tsk := Sequence(tasks...)
+	assert.Equal(t, 100_000, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+}
+
+func TestBuilder_Add_MustApplyStepTimeout(t *testing.T) {
+	t.Parallel()
+	slow := func() error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	tsk := (&Builder{}).Add(nil).Add(slow, StepTimeout(time.Millisecond*50)).Add(nil).Task()
+
+	start := time.Now()
+	err := tsk.Run(context.Background())
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+	assert.ErrorIs(t, err, ErrStepTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "step 1: context deadline exceeded: step timeout after 50ms")
+
+	var stepErr *StepError
+	if assert.ErrorAs(t, err, &stepErr) {
+		assert.Equal(t, 1, stepErr.Index)
+	}
+}
+
+func TestBuilder_Add_MustTellStepTimeout_FromOuterDeadline(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	tsk := (&Builder{}).Add(func() error {
+		time.Sleep(time.Second)
+		return nil
+	}, StepTimeout(time.Minute)).Task()
+
+	err := RunSync(ctx, Named("slow", tsk))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, ErrStepTimeout)
+	assert.EqualError(t, err, "task 'slow' (step 0): context deadline exceeded")
+}
+
+func TestBuilder_Add_MustKeepResult_WithinStepTimeout(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := (&Builder{}).Add(func() error { return failure }, StepTimeout(time.Second), nil).Task()
+	assert.Equal(t, failure, tsk.Run(context.Background()), "must not identify other errors than timeouts")
+}
+
+func TestBuilder_Add_MustKeepTask_WithStepTimeout(t *testing.T) {
+	t.Parallel()
+	tsk := (&Builder{}).Add(func() error { return nil }, func(t *task) *task {
+		t.tags, t.await = map[string]string{"k": "v"}, true
+		return t
+	}, StepTimeout(time.Second)).Task()
+
+	node := tsk.(*task)
+	assert.Equal(t, map[string]string{"k": "v"}, node.tags)
+	assert.True(t, node.await)
+	assert.NoError(t, tsk.Run(context.Background()))
+}
@@ -0,0 +1,87 @@
+package grace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Count returns the number of tasks in the chain, starting from given Task.
+// Nil Task is considered as an empty chain, thus returns 0.
+func Count(t Task) int {
+	n := 0
+	for ; !isNil(t); t = t.Next() {
+		n++
+	}
+	return n
+}
+
+// countSafe is Count which stops counting at a panic from Next of others, as the run recovers from it anyway.
+func countSafe(t Task) (n int) {
+	defer func() { _ = recover() }()
+	for ; !isNil(t); t = t.Next() {
+		n++
+	}
+	return n
+}
+
+// isNil reports whether given Task is nil, including typed nil of the task.
+func isNil(t Task) bool {
+	if t == nil {
+		return true
+	}
+	tt, ok := t.(*task)
+	return ok && tt == nil
+}
+
+// describeLimit is the number of tasks shown by Describe before truncation.
+const describeLimit = 8
+
+// Describe returns a structural description of the chain without running it, like `stop-http -> close-db (2 steps)`.
+// Tasks without name are shown by their index, and long chains are truncated with the last task kept.
+func Describe(t Task) string {
+	labels, n := make([]string, 0, describeLimit), 0
+	last := ""
+	for ; !isNil(t); t = t.Next() {
+		last = label(t, n)
+		if n < describeLimit {
+			labels = append(labels, last)
+		}
+		n++
+	}
+	switch {
+	case n > describeLimit+1:
+		labels = append(labels, fmt.Sprintf("... %d more", n-describeLimit-1), last)
+	case n == describeLimit+1: // nothing to truncate but the last one
+		labels = append(labels, last)
+	}
+	unit := "steps"
+	if n == 1 {
+		unit = "step"
+	}
+	if n == 0 {
+		return fmt.Sprintf("(0 %s)", unit)
+	}
+	return fmt.Sprintf("%s (%d %s)", strings.Join(labels, " -> "), n, unit)
+}
+
+// label returns the name of given Task, or its index in the chain when not named.
+func label(t Task, index int) string {
+	if name := nameOf(t); name != "" {
+		return name
+	}
+	return "#" + strconv.Itoa(index)
+}
+
+// infoOf returns StepInfo of given Task at the index.
+func infoOf(t Task, index int) StepInfo {
+	return StepInfo{Index: index, Name: nameOf(t), Tags: tagsOf(t)}
+}
+
+// nameOf returns the name of given Task if any.
+func nameOf(t Task) string {
+	if tt, ok := t.(*task); ok && tt != nil {
+		return tt.name
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package grace
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCount_MustReturnZero_WhenNil(t *testing.T) {
+	t.Parallel()
+	var tt *task
+	assert.Equal(t, 0, Count(nil))
+	assert.Equal(t, 0, Count(tt))
+}
+
+func TestCount_MustCountEveryTask(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 1, Count(With(nil)))
+	assert.Equal(t, 3, Count(With(nil).Then(With(nil)).Then(With(nil))))
+
+	tsk := With(nil).Then(With(nil))
+	tsk.Then(With(nil))
+	assert.Equal(t, 2, Count(tsk), "must not be affected by chaining")
+}
+
+func TestCount_MustCountLongChain(t *testing.T) {
+	t.Parallel()
+	tsk := With(nil)
+	for i := 1; i < 100_000; i++ {
+		tsk = With(nil).Then(tsk)
+	}
+	assert.Equal(t, 100_000, Count(tsk))
+}
+
+func TestDescribe_MustListNamesOrIndices(t *testing.T) {
+	t.Parallel()
+	tsk := Named("stop-http", With(nil)).Then(With(nil)).Then(Named("close-db", With(nil)))
+	assert.Equal(t, "stop-http -> #1 -> close-db (3 steps)", Describe(tsk))
+	assert.Equal(t, "#0 (1 step)", Describe(With(nil)))
+	assert.Equal(t, "(0 steps)", Describe(nil))
+}
+
+func TestDescribe_MustTruncateLongChain(t *testing.T) {
+	t.Parallel()
+	tsk := Named("last", With(nil))
+	for i := 0; i < 19; i++ {
+		tsk = With(nil).Then(tsk)
+	}
+	assert.Equal(t, "#0 -> #1 -> #2 -> #3 -> #4 -> #5 -> #6 -> #7 -> ... 11 more -> last (20 steps)", Describe(tsk))
+}
+
+func TestDescribe_MustListEveryStep_WhenOnlyLastOneExceedsLimit(t *testing.T) {
+	t.Parallel()
+	tsk := Named("last", With(nil))
+	for i := 0; i < describeLimit; i++ {
+		tsk = With(nil).Then(tsk)
+	}
+	assert.Equal(t, "#0 -> #1 -> #2 -> #3 -> #4 -> #5 -> #6 -> #7 -> last (9 steps)", Describe(tsk))
+	assert.Equal(t, "#0 -> #1 -> #2 -> #3 -> #4 -> #5 -> #6 -> #7 -> ... 1 more -> last (10 steps)", Describe(With(nil).Then(tsk)))
+}
+
+func TestDescribe_MustNotRunSteps(t *testing.T) {
+	t.Parallel()
+	count := 0
+	tsk := WithNoErr(func() { count++ }).Then(WithNoErr(func() { count++ }))
+	assert.Equal(t, "#0 -> #1 (2 steps)", Describe(tsk))
+	assert.Equal(t, 0, count)
+}
+
+func TestNamed_MustNotAffectGivenTask(t *testing.T) {
+	t.Parallel()
+	tsk := With(nil)
+	named := Named("named", tsk)
+	assert.Equal(t, "named", nameOf(named))
+	assert.Equal(t, "", nameOf(tsk))
+	assert.Equal(t, "named", nameOf(Named("named", nil)))
+}
@@ -0,0 +1,22 @@
+package grace
+
+import "context"
+
+// Choose returns new Task that evaluates the selector on every each run, then runs the branch at the index returned.
+// When the index is out of range, or the selector is nil, it does nothing. A panic from the selector is recovered
+// as same as those from steps.
+func Choose(selector func() int, branches ...Task) Task {
+	branches = append([]Task(nil), branches...) // let the caller reuse the slice
+	t := withCtx(func(ctx context.Context) error {
+		if selector == nil {
+			return nil
+		}
+		i := selector()
+		if i < 0 || i >= len(branches) || isNil(branches[i]) {
+			return nil
+		}
+		return branches[i].Run(ctx)
+	})
+	t.await = true // the branch is run with given context, hence returns promptly on cancellation
+	return t
+}
@@ -0,0 +1,47 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChoose_MustRunSelectedBranch(t *testing.T) {
+	t.Parallel()
+	var ran []string
+	branch := func(name string) Task {
+		return WithNoErr(func() { ran = append(ran, name) })
+	}
+	selected := 0
+	tsk := Choose(func() int { return selected }, branch("a"), branch("b"), nil)
+
+	for _, selected = range []int{1, 0, 1} {
+		assert.NoError(t, tsk.Run(context.Background()))
+	}
+	assert.Equal(t, []string{"b", "a", "b"}, ran)
+
+	failure := errors.New("failure")
+	tsk = Choose(func() int { return 0 }, With(func() error { return failure }))
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+}
+
+func TestChoose_MustDoNothing_WhenOutOfRange(t *testing.T) {
+	t.Parallel()
+	ran := false
+	branch := WithNoErr(func() { ran = true })
+	for _, index := range []int{-1, 1, 2} {
+		index := index
+		assert.NoError(t, Choose(func() int { return index }, branch, nil).Run(context.Background()))
+	}
+	assert.NoError(t, Choose(nil, branch).Run(context.Background()))
+	assert.False(t, ran)
+}
+
+func TestChoose_MustRecoverPanic_FromSelector(t *testing.T) {
+	t.Parallel()
+	err := Choose(func() int { panic("selector") }, With(nil)).Run(context.Background())
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.EqualError(t, err, "selector")
+}
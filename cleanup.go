@@ -0,0 +1,60 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// OnCleanup registers given function with the context given to a step by a run, to be called once the run of the
+// chain finishes, even on error or panic. Functions are called in reverse order of registration like defer statements.
+// Panics from functions are converted into errors, then joined with the error of the run.
+// Registered after the run finishes, such as from a goroutine left by the step, fn is called immediately.
+// Outside of a run, it does nothing.
+func OnCleanup(ctx context.Context, fn func()) {
+	if c, ok := ctx.Value(cleanupKey{}).(*cleanups); ok && fn != nil {
+		c.add(fn)
+	}
+}
+
+// cleanupKey is the context key of cleanups.
+type cleanupKey struct{}
+
+// cleanups are functions registered by OnCleanup during a run.
+type cleanups struct {
+	mu   sync.Mutex
+	fns  []func()
+	done bool
+}
+
+// withCleanup returns a copy of the context which OnCleanup registers functions with, and the function to call them.
+func withCleanup(ctx context.Context) (context.Context, func() error) {
+	c := &cleanups{}
+	return context.WithValue(ctx, cleanupKey{}, c), c.run
+}
+
+// add registers fn, or calls it immediately when cleanups have run already.
+func (c *cleanups) add(fn func()) {
+	c.mu.Lock()
+	if !c.done {
+		c.fns = append(c.fns, fn)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	_ = guard(func() error { fn(); return nil })
+}
+
+// run calls every each function registered in reverse order, then returns errors of panics joined.
+func (c *cleanups) run() error {
+	c.mu.Lock()
+	fns := c.fns
+	c.fns, c.done = nil, true
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		errs = append(errs, guard(func() error { fns[i](); return nil }))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOnCleanup_MustRunInReverseOrder_AfterChain(t *testing.T) {
+	t.Parallel()
+	var order []string
+	register := func(name string) Task {
+		return WithCtx(func(ctx context.Context) error {
+			OnCleanup(ctx, func() { order = append(order, "cleanup "+name) })
+			order = append(order, name)
+			return nil
+		})
+	}
+	tsk := register("a").Then(register("b")).Then(WithNoErr(func() { order = append(order, "last") }))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []string{"a", "b", "last", "cleanup b", "cleanup a"}, order)
+}
+
+func TestOnCleanup_MustRun_WhenFailedOrPanicked(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	for _, last := range []Task{With(func() error { return failure }), WithNoErr(func() { panic(failure) })} {
+		cleaned := 0
+		tsk := WithCtx(func(ctx context.Context) error {
+			OnCleanup(ctx, func() { cleaned++ })
+			return nil
+		}).Then(last)
+
+		assert.ErrorIs(t, RunSync(context.Background(), tsk), failure)
+		assert.Equal(t, 1, cleaned)
+	}
+}
+
+func TestOnCleanup_MustJoinPanics_FromCleanup(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	ran := false
+	tsk := WithCtx(func(ctx context.Context) error {
+		OnCleanup(ctx, func() { ran = true })
+		OnCleanup(ctx, func() { panic("cleanup panicked") })
+		return failure
+	})
+
+	err := tsk.Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorContains(t, err, "cleanup panicked")
+	assert.True(t, ran, "must run the rest")
+}
+
+func TestOnCleanup_MustCallImmediately_AfterRunFinished(t *testing.T) {
+	t.Parallel()
+	var stepCtx context.Context
+	assert.NoError(t, WithCtx(func(ctx context.Context) error {
+		stepCtx = ctx
+		return nil
+	}).Run(context.Background()))
+
+	called := false
+	OnCleanup(stepCtx, func() { called = true })
+	assert.True(t, called)
+
+	OnCleanup(context.Background(), func() { t.Fatal("must do nothing outside of a run") })
+	OnCleanup(stepCtx, nil)
+}
@@ -0,0 +1,90 @@
+package grace
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clock tells the time to time-based helpers, such as retries and Every, so that tests can control it.
+// Deadlines of contexts, such as those of WithTimeout, are not affected, as they are managed by the context package.
+type clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a channel that receives the time once given duration has elapsed, and the function to stop it,
+	// which tells whether the timer has been stopped before it fires. Stop it once not waiting, so that it is released.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+	// NewTicker returns a channel that receives the time on every each duration, and the function to stop it.
+	// Ticks are dropped for slow receivers, as time.Ticker does.
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+	// Sleep pauses the goroutine for given duration.
+	Sleep(d time.Duration)
+}
+
+// realClock is the clock of the system.
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+func (realClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+// clockBox holds a clock, as atomic.Pointer requires a concrete type.
+type clockBox struct {
+	clock
+}
+
+// clocks holds the clock in use, which tests replace.
+var clocks atomic.Pointer[clockBox]
+
+func init() {
+	clocks.Store(&clockBox{realClock{}})
+}
+
+// now returns the clock in use.
+func now() clock {
+	return clocks.Load().clock
+}
+
+// alarm fires once its duration has elapsed since it is reset last, as measured by the clock in use.
+// Resetting costs no timer, as it rearms only when the previous one fires. Stop it once not waiting.
+type alarm struct {
+	d     time.Duration
+	last  time.Time
+	C     <-chan time.Time
+	timer func() bool // stops the timer of C
+}
+
+// newAlarm returns new alarm of given duration, which starts right away.
+func newAlarm(d time.Duration) *alarm {
+	a := &alarm{d: d, last: now().Now()}
+	a.C, a.timer = now().NewTimer(d)
+	return a
+}
+
+// stop releases the timer of the alarm.
+func (a *alarm) stop() {
+	a.timer()
+}
+
+// reset starts the duration over.
+func (a *alarm) reset() {
+	a.last = now().Now()
+}
+
+// fired tells whether the duration has elapsed since reset, once C has received. Otherwise, it rearms C for the rest.
+func (a *alarm) fired() bool {
+	rest := a.last.Add(a.d).Sub(now().Now())
+	if rest <= 0 {
+		return true
+	}
+	a.C, a.timer = now().NewTimer(rest)
+	return false
+}
@@ -0,0 +1,214 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which time passes only by Advance.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration // of tickers
+	ch     chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch, func() bool { return false }
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch, func() bool { return c.remove(ch) }
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), period: d, ch: ch})
+	return ch, func() { c.remove(ch) }
+}
+
+// remove removes the waiter of given channel, then tells whether it has been waiting.
+func (c *fakeClock) remove(ch chan time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w.ch == ch {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	ch, _ := c.NewTimer(d)
+	<-ch
+}
+
+// Advance passes the time by given duration, firing waiters due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		select {
+		case w.ch <- c.now:
+		default: // a tick is pending already
+		}
+		if w.period > 0 { // rearm the ticker for the next tick
+			for !w.at.After(c.now) {
+				w.at = w.at.Add(w.period)
+			}
+			waiters = append(waiters, w)
+		}
+	}
+	c.waiters = waiters
+}
+
+// AwaitWaiters blocks until given number of waiters are waiting for the time to pass.
+func (c *fakeClock) AwaitWaiters(t *testing.T, n int) {
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.waiters) >= n
+	}, time.Second, time.Millisecond)
+}
+
+// useFakeClock replaces the clock in use by a fakeClock for the duration of the test.
+// Tests using it must not be parallel, as the clock is shared across the package.
+func useFakeClock(t *testing.T) *fakeClock {
+	c := &fakeClock{now: time.Date(2022, 8, 30, 0, 0, 0, 0, time.UTC)}
+	previous := clocks.Swap(&clockBox{c})
+	t.Cleanup(func() { clocks.Store(previous) })
+	return c
+}
+
+func TestClock_MustTriggerRetries_WithoutSleeping(t *testing.T) {
+	c := useFakeClock(t)
+	attempts := &atomic.Int32{}
+	failure := errors.New("failure")
+	tsk := WithRetry(func() error {
+		attempts.Add(1)
+		return failure
+	}, 3, time.Hour)
+
+	errChan := make(chan error, 1)
+	start := time.Now()
+	go func() { errChan <- RunSync(context.Background(), tsk) }()
+
+	c.AwaitWaiters(t, 1)
+	assert.Equal(t, int32(1), attempts.Load())
+	c.Advance(time.Hour)
+	c.AwaitWaiters(t, 1)
+	assert.Equal(t, int32(2), attempts.Load())
+	c.Advance(time.Hour)
+
+	assert.ErrorIs(t, <-errChan, failure)
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestClock_MustScheduleEvery_WithoutDrift(t *testing.T) {
+	c := useFakeClock(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := &atomic.Int32{}
+	tsk := Every(WithNoErr(func() {
+		runs.Add(1)
+		c.Advance(time.Second * 3) // takes 3s of a 10s interval
+	}), time.Second*10)
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- RunSync(ctx, tsk) }()
+	for i := 1; i <= 3; i++ {
+		c.AwaitWaiters(t, 1)
+		assert.Equal(t, int32(i), runs.Load())
+		c.Advance(time.Second * 7)
+	}
+	c.AwaitWaiters(t, 1)
+	assert.Equal(t, time.Date(2022, 8, 30, 0, 0, 33, 0, time.UTC), c.Now(), "must run at 0s, 10s, 20s, 30s")
+	assert.Equal(t, int32(4), runs.Load())
+	cancel()
+	assert.NoError(t, <-errChan)
+}
+
+func TestClock_MustExpireMemo(t *testing.T) {
+	c := useFakeClock(t)
+	count := 0
+	tsk := Memo(WithNoErr(func() { count++ }), time.Minute)
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	c.Advance(time.Second * 59)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 1, count)
+	c.Advance(time.Second)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 2, count)
+}
+
+func TestClock_MustFireWatchdog_OnlyAfterQuiet(t *testing.T) {
+	c := useFakeClock(t)
+	release, errChan := make(chan struct{}), make(chan error, 1)
+	tsk := Watchdog(WithCtx(func(ctx context.Context) error {
+		c.Advance(time.Second * 30)
+		Heartbeat(ctx)
+		<-release
+		return nil
+	}), time.Minute)
+	go func() { errChan <- RunSync(context.Background(), tsk) }()
+
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Second * 30) // a minute since start, but only 30s since the beat
+	c.AwaitWaiters(t, 1)
+	select {
+	case err := <-errChan:
+		t.Fatalf("must not fire before quiet, but got %v", err)
+	default:
+	}
+	c.Advance(time.Second * 30)
+	assert.ErrorIs(t, <-errChan, ErrWatchdog)
+	close(release)
+	assert.Eventually(t, func() bool { return WatchdogLeaks() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestClock_MustStopTimer_WhenBackoffCanceled(t *testing.T) {
+	c := useFakeClock(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk := WithRetry(func() error { return errors.New("failure") }, 3, time.Hour)
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- RunSync(ctx, tsk) }()
+	c.AwaitWaiters(t, 1)
+	cancel()
+
+	assert.ErrorIs(t, <-errChan, context.Canceled)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Empty(t, c.waiters, "must release the timer")
+}
@@ -0,0 +1,68 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	osexec "os/exec"
+	"strings"
+	"time"
+)
+
+// CommandOption configures the command run by CommandWith.
+type CommandOption func(cmd *osexec.Cmd)
+
+// CommandDir returns CommandOption that runs the command in given working directory.
+func CommandDir(dir string) CommandOption {
+	return func(cmd *osexec.Cmd) {
+		cmd.Dir = dir
+	}
+}
+
+// CommandEnv returns CommandOption that runs the command with given environment variables of "key=value" form,
+// in addition to those of the current process.
+func CommandEnv(env ...string) CommandOption {
+	return func(cmd *osexec.Cmd) {
+		cmd.Env = append(cmd.Environ(), env...)
+	}
+}
+
+// CommandStdout returns CommandOption that writes the standard output of the command into given writer.
+func CommandStdout(w io.Writer) CommandOption {
+	return func(cmd *osexec.Cmd) {
+		cmd.Stdout = w
+	}
+}
+
+// Command returns new Task that runs the command of given name and arguments, as CommandWith does with no options.
+func Command(name string, args ...string) Task {
+	return CommandWith(name, args)
+}
+
+// CommandWith returns new Task that runs the command of given name and arguments with the context given to Run.
+// When the command exits with non-zero status, the error tells its standard error as well.
+// When the context is done, the whole process group of the command is killed where supported, such as on unix,
+// so that child processes never linger.
+func CommandWith(name string, args []string, opts ...CommandOption) Task {
+	args = append([]string(nil), args...) // let the caller reuse the slice
+	return withCtx(func(ctx context.Context) error {
+		stderr := &bytes.Buffer{}
+		cmd := osexec.CommandContext(ctx, name, args...)
+		cmd.Stderr = stderr
+		cmd.WaitDelay = time.Second // never hang on pipes held by others once killed
+		for _, opt := range opts {
+			if opt != nil {
+				opt(cmd)
+			}
+		}
+		killProcessGroup(cmd)
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("%w: %s", err, msg)
+			}
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package grace
+
+import osexec "os/exec"
+
+// killProcessGroup does nothing, as process groups are not supported; the command alone is killed on cancellation.
+func killProcessGroup(*osexec.Cmd) {}
@@ -0,0 +1,71 @@
+//go:build unix
+
+package grace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCommand_MustRunCommand_WithOptions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+	tsk := CommandWith("sh", []string{"-c", "echo $GRACE_TEST; pwd"},
+		CommandDir(dir), CommandEnv("GRACE_TEST=hello"), CommandStdout(stdout), nil)
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	resolved, _ := filepath.EvalSymlinks(dir)
+	assert.Equal(t, "hello\n"+resolved+"\n", stdout.String())
+	assert.NoError(t, Command("true").Run(context.Background()))
+}
+
+func TestCommand_MustTellStderr_WhenFailed(t *testing.T) {
+	t.Parallel()
+	err := Command("sh", "-c", "echo broken >&2; exit 3").Run(context.Background())
+	var exitErr *osexec.ExitError
+	if assert.ErrorAs(t, err, &exitErr) {
+		assert.Equal(t, 3, exitErr.ExitCode())
+	}
+	assert.EqualError(t, err, "exit status 3: broken")
+}
+
+func TestCommand_MustKillProcessGroup_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.Eventually(t, func() bool {
+			b, err := os.ReadFile(pidFile)
+			return err == nil && strings.HasSuffix(string(b), "\n")
+		}, time.Second*5, time.Millisecond*10)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := RunSync(ctx, Command("sh", "-c", "sleep 30 & echo $! > "+pidFile+"; wait"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second*10)
+
+	b, readErr := os.ReadFile(pidFile)
+	assert.NoError(t, readErr)
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(b)))
+	assert.Eventually(t, func() bool {
+		stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+		if err != nil {
+			return errors.Is(syscall.Kill(pid, 0), syscall.ESRCH)
+		}
+		return strings.Contains(string(stat), ") Z ") // killed, yet left unreaped by the init of containers
+	}, time.Second, time.Millisecond*10, "child must be killed")
+}
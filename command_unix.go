@@ -0,0 +1,16 @@
+//go:build unix
+
+package grace
+
+import (
+	osexec "os/exec"
+	"syscall"
+)
+
+// killProcessGroup makes the command run in its own process group, which is killed as a whole on cancellation.
+func killProcessGroup(cmd *osexec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
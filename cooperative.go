@@ -0,0 +1,58 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RunCooperative runs given Task on the calling goroutine as same as RunSync does, but gives every each step its own
+// deadline of perStep, derived from the context. Steps aware of the context, such as those by WithCtx, are canceled
+// cooperatively on their deadline, which is told by ErrStepTimeout along with context.DeadlineExceeded when they
+// fail with it. Plain steps, such as those by With, cannot be canceled at all: a warning is logged through the logger
+// of the context as they exceed the deadline, then they are waited for to finish as they are.
+// Steps of chains run by steps with the context, such as All, are given their own deadlines as well.
+// Non-positive perStep runs as same as RunSync does.
+func RunCooperative(ctx context.Context, t Task, perStep time.Duration) error {
+	return RunSync(context.WithValue(ctx, perStepKey{}, perStep), t)
+}
+
+// perStepKey is the context key of the deadline of every each step. See RunCooperative.
+type perStepKey struct{}
+
+// withStepDeadline returns the context of given Task, under its own deadline d given by RunCooperative, if positive.
+// The returned function must be called with the error of the step once it returns, which tells the deadline if hit.
+func withStepDeadline(ctx context.Context, t Task, d time.Duration) (context.Context, func(err error) error) {
+	if d <= 0 {
+		return ctx, func(err error) error { return err }
+	}
+	if node, ok := t.(*task); ok && node.run != nil {
+		ctx, cancel := context.WithTimeoutCause(ctx, d, fmt.Errorf("%w after %v", ErrStepTimeout, d))
+		return ctx, func(err error) error {
+			defer cancel()
+			if err != nil && errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrStepTimeout) {
+				if cause := context.Cause(ctx); errors.Is(cause, ErrStepTimeout) {
+					return fmt.Errorf("%w: %w", err, cause)
+				}
+			}
+			return err
+		}
+	}
+	done, warned := make(chan struct{}), make(chan struct{})
+	timer, stop := now().NewTimer(d)
+	go func() {
+		defer close(warned)
+		defer stop()
+		select {
+		case <-done:
+		case <-timer:
+			LoggerFrom(ctx).Warn("grace: step exceeded its deadline, waiting as it cannot be canceled", "deadline", d)
+		}
+	}()
+	return ctx, func(err error) error {
+		close(done)
+		<-warned // never warns after the step returned
+		return err
+	}
+}
@@ -0,0 +1,59 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRunCooperative_MustCancelStepCtx_OnItsDeadline(t *testing.T) {
+	t.Parallel()
+	ran := false
+	tsk := WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).Then(WithNoErr(func() { ran = true }))
+
+	start := time.Now()
+	err := RunCooperative(context.Background(), tsk, time.Millisecond*20)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, ErrStepTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, ran)
+}
+
+func TestRunCooperative_MustGiveEveryStep_ItsOwnDeadline(t *testing.T) {
+	t.Parallel()
+	step := WithCtx(func(ctx context.Context) error { return sleep(ctx, time.Millisecond*20) })
+
+	assert.NoError(t, RunCooperative(context.Background(), step.Then(step).Then(step), time.Millisecond*200))
+	assert.ErrorIs(t, RunCooperative(context.Background(), Sleep(time.Hour), time.Millisecond*20), ErrStepTimeout)
+}
+
+func TestRunCooperative_MustWarnAndWait_ForPlainStep(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(buf, nil)))
+	finished, ran := false, false
+	tsk := Named("flush", WithNoErr(func() {
+		time.Sleep(time.Millisecond * 100)
+		finished = true
+	})).Then(WithNoErr(func() { ran = true }))
+
+	assert.NoError(t, RunCooperative(ctx, tsk, time.Millisecond*10))
+	assert.True(t, finished)
+	assert.True(t, ran)
+	assert.Contains(t, buf.String(), `level=WARN msg="grace: step exceeded its deadline, waiting as it cannot be canceled" step_index=0 step=flush deadline=10ms`)
+}
+
+func TestRunCooperative_MustRunAsRunSync_WhenNotPositive(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, RunCooperative(context.Background(), WithCtx(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil
+	}), 0))
+}
@@ -0,0 +1,188 @@
+package grace
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCronSpec is the error of a cron spec which Cron fails to parse.
+var ErrCronSpec = errors.New("invalid cron spec")
+
+// Cron starts running given Task in background on the schedule of a standard cron spec, as same as RunEvery does
+// on its interval, in the location of the clock, which is local by default. The spec is either 5 fields of minute,
+// hour, day of month, month and day of week, such as "*/15 9-17 * * MON-FRI", or one of shortcuts: @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly. Fields take lists, ranges and steps, as well as names
+// of months and days. As cron does, a day matches either of restricted day of month and day of week.
+// A spec failing to parse, or never to fire, returns an error wrapping ErrCronSpec, without starting anything.
+// Ticks passed while a run is in progress are dropped, and counted by PeriodicRunner.Skipped.
+func Cron(spec string, t Task, opts ...PeriodicOption) (*PeriodicRunner, error) {
+	s, err := parseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return startPeriodic(t, s.next, opts), nil
+}
+
+// cronShortcuts are specs of shortcuts.
+var cronShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField is the range and names of values of a field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names of values from min, if any
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// cronSchedule is a parsed cron spec, which every each field is a set of values by bits.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// anyDay tells either of day of month and day of week starts with "*", thus days must match both of them.
+	anyDay bool
+}
+
+// cronHorizon bounds the search for the next time, beyond which a schedule is considered never to fire.
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+// parseCron parses given cron spec, which must fire at least once.
+func parseCron(spec string) (*cronSchedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if strings.HasPrefix(expanded, "@") {
+		shortcut, ok := cronShortcuts[strings.ToLower(expanded)]
+		if !ok {
+			return nil, fmt.Errorf("%w %q: unknown shortcut", ErrCronSpec, spec)
+		}
+		expanded = shortcut
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w %q: expected %d fields, got %d", ErrCronSpec, spec, len(cronFields), len(fields))
+	}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := cronFields[i].parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s: %w", ErrCronSpec, spec, cronFields[i].name, err)
+		}
+		sets[i] = set
+	}
+	if sets[4]&(1<<7) != 0 { // 7 is also Sunday
+		sets[4] |= 1
+	}
+	s := &cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		anyDay: strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*"),
+	}
+	if s.next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("%w %q: never fires", ErrCronSpec, spec)
+	}
+	return s, nil
+}
+
+// parse parses a field of comma separated values, ranges and steps into a set of values by bits.
+func (f cronField) parse(field string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			expr, step = part[:i], n
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case expr == "*":
+		case strings.Contains(expr, "-"):
+			bounds := strings.SplitN(expr, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", expr)
+			}
+		default:
+			v, err := f.value(expr)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 { // a single value, unless stepped up to the max as cron does
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a value of the field, either a number or a name.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q, expected %d-%d", s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// next returns the first time matching the schedule after given time, in its location.
+// Zero time is returned when none is found within cronHorizon.
+func (s *cronSchedule) next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, loc)
+	limit := t.Add(cronHorizon)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay tells whether the day of given time matches the schedule.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dom, dow := s.dom&(1<<uint(t.Day())) != 0, s.dow&(1<<uint(t.Weekday())) != 0
+	if s.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
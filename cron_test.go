@@ -0,0 +1,74 @@
+package grace
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCron_MustComputeNext(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2022, 8, 30, 10, 7, 30, 0, time.UTC) // Tuesday
+	for spec, want := range map[string]time.Time{
+		"* * * * *":              time.Date(2022, 8, 30, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":           time.Date(2022, 8, 30, 10, 15, 0, 0, time.UTC),
+		"5 * * * *":              time.Date(2022, 8, 30, 11, 5, 0, 0, time.UTC),
+		"0 9-17 * * MON-FRI":     time.Date(2022, 8, 30, 11, 0, 0, 0, time.UTC),
+		"30 8 * * sat,sun":       time.Date(2022, 9, 3, 8, 30, 0, 0, time.UTC),
+		"0 0 * * 7":              time.Date(2022, 9, 4, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":              time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":             time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 1 JAN-MAR/2 *":      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		"10/20 10 * * *":         time.Date(2022, 8, 30, 10, 10, 0, 0, time.UTC),
+		"0 12 15 * FRI":          time.Date(2022, 9, 2, 12, 0, 0, 0, time.UTC), // either day of month or day of week
+		"0 12 1,2,3,4,5 * */100": time.Date(2022, 9, 4, 12, 0, 0, 0, time.UTC), // both, as day of week starts with *: Sunday
+		"@hourly":                time.Date(2022, 8, 30, 11, 0, 0, 0, time.UTC),
+		"@daily":                 time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC),
+		"@weekly":                time.Date(2022, 9, 4, 0, 0, 0, 0, time.UTC),
+		"@monthly":               time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+		"@yearly":                time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		s, err := parseCron(spec)
+		if assert.NoError(t, err, spec) {
+			assert.Equal(t, want, s.next(from), spec)
+		}
+	}
+}
+
+func TestCron_MustFail_OnInvalidSpec(t *testing.T) {
+	t.Parallel()
+	for _, spec := range []string{
+		"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *", "* * * FOO *", "@every", "0 0 30 2 *",
+	} {
+		p, err := Cron(spec, With(nil))
+		assert.ErrorIs(t, err, ErrCronSpec, spec)
+		assert.Nil(t, p, spec)
+	}
+}
+
+func TestCron_MustRunOnSchedule_AndCountSkipped(t *testing.T) { // not parallel, as the clock is replaced
+	c := useFakeClock(t) // starts at 00:00
+	runs := &atomic.Int32{}
+	release := make(chan struct{})
+	p, err := Cron("*/10 * * * *", WithNoErr(func() {
+		if runs.Add(1) == 1 {
+			<-release
+		}
+	}))
+	assert.NoError(t, err)
+
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute * 10) // 00:10, runs until 00:35
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+	c.Advance(time.Minute * 25)
+	close(release)
+
+	c.AwaitWaiters(t, 1)
+	assert.Equal(t, int64(2), p.Skipped(), "must skip 00:20 and 00:30")
+	c.Advance(time.Minute * 5) // 00:40
+	assert.Eventually(t, func() bool { return runs.Load() == 2 }, time.Second, time.Millisecond)
+	assert.NoError(t, p.Stop(context.Background()))
+}
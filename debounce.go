@@ -0,0 +1,151 @@
+package grace
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DebounceOption configures Debouncer.
+type DebounceOption func(d *Debouncer)
+
+// WithDebounceErrorCallback returns DebounceOption that gives errors of runs to fn. A panic from fn is recovered.
+// Errors of runs canceled by Close are not given.
+func WithDebounceErrorCallback(fn func(err error)) DebounceOption {
+	return func(d *Debouncer) {
+		d.onError = fn
+	}
+}
+
+// Debouncer runs a chain once triggers have been quiet for a while, coalescing bursts of them into a single run.
+// See Debounce.
+type Debouncer struct {
+	task    Task
+	quiet   time.Duration
+	onError func(err error)
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	triggers  chan struct{}
+	mu        sync.Mutex
+	last      time.Time // of the last Trigger
+	closing   chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Debounce returns new Debouncer, which runs given Task in background once quiet has passed since the last Trigger,
+// under a context of its own which only Close cancels. Runs never overlap; triggers during a run schedule exactly
+// one run to follow, once quiet has passed again after the run.
+//
+//	reindex := grace.Debounce(index, time.Second)
+//	defer reindex.Close(ctx)
+//	for range events {
+//		reindex.Trigger()
+//	}
+func Debounce(t Task, quiet time.Duration, opts ...DebounceOption) *Debouncer {
+	if isNil(t) {
+		t = With(nil)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Debouncer{
+		task:     t,
+		quiet:    quiet,
+		ctx:      ctx,
+		cancel:   cancel,
+		triggers: make(chan struct{}, 1),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	go d.loop()
+	return d
+}
+
+// Trigger schedules a run after quiet, postponing the run already scheduled if any. It is safe for concurrent use,
+// and does nothing once Close is called.
+func (d *Debouncer) Trigger() {
+	select {
+	case <-d.closing:
+		return
+	default:
+	}
+	d.mu.Lock()
+	d.last = now().Now()
+	d.mu.Unlock()
+	select {
+	case d.triggers <- struct{}{}:
+	default: // pending already
+	}
+}
+
+// Close stops the Debouncer: a run scheduled is flushed, that is, started right away without waiting for quiet,
+// then it waits for the run in progress to finish, if any. When the context is done first, the run is canceled,
+// or never started, and the error of the context is returned. It can be called multiple times.
+func (d *Debouncer) Close(ctx context.Context) error {
+	if ctx.Err() != nil { // cancel the flush before it starts
+		d.cancel()
+	}
+	d.closeOnce.Do(func() { close(d.closing) })
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		return ctxErr(ctx)
+	}
+}
+
+// loop runs the Task on triggers, until closed.
+func (d *Debouncer) loop() {
+	defer close(d.done)
+	defer d.cancel()
+	for {
+		select {
+		case <-d.triggers:
+		case <-d.closing:
+			select {
+			case <-d.triggers: // flush
+				d.run()
+			default:
+			}
+			return
+		}
+		d.settle()
+		select {
+		case <-d.triggers: // triggered during quiet, which this run covers
+		default:
+		}
+		d.run()
+	}
+}
+
+// settle waits for quiet to pass since the last trigger, or for Close.
+func (d *Debouncer) settle() {
+	for {
+		d.mu.Lock()
+		rest := d.last.Add(d.quiet).Sub(now().Now())
+		d.mu.Unlock()
+		if rest <= 0 {
+			return
+		}
+		timer, stop := now().NewTimer(rest) // rearmed only when it fires, however many triggers
+		select {
+		case <-timer:
+		case <-d.closing: // flush
+			stop()
+			return
+		}
+	}
+}
+
+// run runs the Task, giving its error to the callback if any.
+func (d *Debouncer) run() {
+	if err := d.task.Run(d.ctx); err != nil && d.ctx.Err() == nil && d.onError != nil {
+		_ = guard(func() error { d.onError(err); return nil })
+	}
+}
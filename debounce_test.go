@@ -0,0 +1,127 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebounce_MustCoalesceBurst_IntoSingleRun(t *testing.T) { // not parallel, as the clock is replaced
+	c := useFakeClock(t)
+	runs := &atomic.Int32{}
+	d := Debounce(WithNoErr(func() { runs.Add(1) }), time.Minute)
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Second * 30)
+	d.Trigger() // postpones the run to 1m30s
+	c.Advance(time.Second * 30)
+	c.AwaitWaiters(t, 1) // rearmed for the rest
+	assert.Equal(t, int32(0), runs.Load())
+	c.Advance(time.Second * 30)
+
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestDebounce_MustScheduleExactlyOneFollowUp_WhenTriggeredDuringRun(t *testing.T) {
+	c := useFakeClock(t)
+	runs := &atomic.Int32{}
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	d := Debounce(WithNoErr(func() {
+		runs.Add(1)
+		started <- struct{}{}
+		<-release
+	}), time.Minute)
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-started
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Trigger()
+		}()
+	}
+	wg.Wait()
+	close(release)
+
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-started
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestDebounce_Close_MustFlushPendingTrigger(t *testing.T) {
+	c := useFakeClock(t)
+	runs := &atomic.Int32{}
+	d := Debounce(WithNoErr(func() { runs.Add(1) }), time.Hour)
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(1), runs.Load(), "must run without waiting for quiet")
+
+	d.Trigger()
+	assert.NoError(t, d.Close(context.Background()), "must be idempotent")
+	assert.Equal(t, int32(1), runs.Load(), "must ignore triggers after close")
+}
+
+func TestDebounce_Close_MustCancelPendingTrigger_WhenContextDone(t *testing.T) {
+	c := useFakeClock(t)
+	runs := &atomic.Int32{}
+	d := Debounce(WithNoErr(func() { runs.Add(1) }), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	assert.ErrorIs(t, d.Close(ctx), context.Canceled)
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(0), runs.Load())
+}
+
+func TestDebounce_Close_MustCancelRunInProgress_WhenContextDone(t *testing.T) {
+	c := useFakeClock(t)
+	started, canceled := make(chan struct{}), make(chan struct{})
+	errs := make(chan error, 1)
+	d := Debounce(WithCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	}), time.Minute, WithDebounceErrorCallback(func(err error) { errs <- err }))
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	assert.ErrorIs(t, d.Close(ctx), context.DeadlineExceeded)
+	<-canceled
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Empty(t, errs, "must not report errors of runs canceled by close")
+}
+
+func TestDebounce_MustReportErrors(t *testing.T) {
+	c := useFakeClock(t)
+	failure := errors.New("failure")
+	errs := make(chan error, 1)
+	d := Debounce(With(func() error { return failure }), time.Minute, WithDebounceErrorCallback(func(err error) { errs <- err }))
+
+	d.Trigger()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	assert.ErrorIs(t, <-errs, failure)
+	assert.NoError(t, d.Close(context.Background()))
+}
@@ -0,0 +1,47 @@
+package grace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dotEscaper escapes characters that are not allowed in quoted DOT strings.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// ToDOT returns a Graphviz DOT digraph of the chain without running it.
+// Tasks are labeled by their names, or their indices when not named. Duplicated names are suffixed by indices.
+func ToDOT(t Task) string {
+	var b strings.Builder
+	b.WriteString("digraph grace {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+	labels := labels(t)
+	for i, l := range labels {
+		fmt.Fprintf(&b, "\tn%d [label=\"%s\"];\n", i, dotEscaper.Replace(l))
+	}
+	for i := 1; i < len(labels); i++ {
+		fmt.Fprintf(&b, "\tn%d -> n%d;\n", i-1, i)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// labels returns labels of every task in the chain, where duplicated names are suffixed by their indices.
+func labels(t Task) []string {
+	names, seen := make([]string, 0), make(map[string]int)
+	for ; !isNil(t); t = t.Next() {
+		name := nameOf(t)
+		names = append(names, name)
+		seen[name]++
+	}
+	for i, name := range names {
+		switch {
+		case name == "":
+			names[i] = "#" + strconv.Itoa(i)
+		case seen[name] > 1:
+			names[i] = name + " #" + strconv.Itoa(i)
+		}
+	}
+	return names
+}
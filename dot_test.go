@@ -0,0 +1,44 @@
+package grace
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToDOT_MustRenderChain(t *testing.T) {
+	t.Parallel()
+	tsk := Named("stop-http", With(nil)).Then(With(nil)).Then(Named(`close "db"`, With(nil)))
+	expected := `digraph grace {
+	rankdir=LR;
+	node [shape=box];
+	n0 [label="stop-http"];
+	n1 [label="#1"];
+	n2 [label="close \"db\""];
+	n0 -> n1;
+	n1 -> n2;
+}
+`
+	assert.Equal(t, expected, ToDOT(tsk))
+	assert.Equal(t, ToDOT(tsk), ToDOT(tsk), "must be deterministic")
+}
+
+func TestToDOT_MustSuffixDuplicatedNames(t *testing.T) {
+	t.Parallel()
+	tsk := Named("save", With(nil)).Then(Named("load", With(nil))).Then(Named("save", With(nil)))
+	expected := `digraph grace {
+	rankdir=LR;
+	node [shape=box];
+	n0 [label="save #0"];
+	n1 [label="load"];
+	n2 [label="save #2"];
+	n0 -> n1;
+	n1 -> n2;
+}
+`
+	assert.Equal(t, expected, ToDOT(tsk))
+}
+
+func TestToDOT_MustRenderEmptyGraph_WhenNil(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "digraph grace {\n\trankdir=LR;\n\tnode [shape=box];\n}\n", ToDOT(nil))
+}
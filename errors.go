@@ -0,0 +1,138 @@
+package grace
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StepError is an error from a named task, which identifies the task by both its name and index,
+// as names are not necessarily unique in the chain. Errors from some unnamed tasks, such as those timed out by
+// StepTimeout, are identified by their index alone.
+type StepError struct {
+	// Index of the task in the chain, starting from zero.
+	Index int
+	// Name of the task.
+	Name string
+	// Tags of the task, as given by WithTags. Nil if none.
+	Tags map[string]string
+	// Err returned from the task.
+	Err error
+}
+
+// Error implements error, which tells the tags of the task if any, sorted by keys.
+func (e *StepError) Error() string {
+	step := fmt.Sprintf("step %d", e.Index)
+	if e.Name != "" {
+		step = fmt.Sprintf("task '%s' (step %d)", e.Name, e.Index)
+	}
+	if tags := formatTags(e.Tags); tags != "" {
+		step += " " + tags
+	}
+	return fmt.Sprintf("%s: %v", step, e.Err)
+}
+
+// Unwrap returns the error from the task.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is an error converted from panic of a task, which identifies the task by its index.
+type PanicError struct {
+	// Index of the task in the chain, starting from zero.
+	Index int
+	// Name of the task, empty if not named.
+	Name string
+	// Value recovered from the panic.
+	Value any
+	// Stack of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error implements error, which tells the value recovered.
+func (e *PanicError) Error() string {
+	return panicErr(e.Value).Error()
+}
+
+// Unwrap returns the value recovered if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Unwrap expands given error into the errors joined in it, such as those by errors.Join, recursively in order.
+// Wrapping errors above the joined one are followed down to it, thus dropped from the result.
+// An error with nothing joined is returned as the only element, while nil error returns nil.
+func Unwrap(err error) []error {
+	if err == nil {
+		return nil
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if joined, ok := e.(interface{ Unwrap() []error }); ok {
+			var errs []error
+			for _, inner := range joined.Unwrap() {
+				errs = append(errs, Unwrap(inner)...)
+			}
+			return errs
+		}
+	}
+	return []error{err}
+}
+
+// ExemptContextErr returns fn for MapError, which leaves errors of context cancellation or deadline as they are,
+// rather than giving them to fn, so that they keep being told by errors.Is.
+func ExemptContextErr(fn func(err error) error) func(err error) error {
+	if fn == nil {
+		return nil
+	}
+	return func(err error) error {
+		if isContextErr(err) {
+			return err
+		}
+		return fn(err)
+	}
+}
+
+// Retryable marks given error as worth retrying, which IsRetryable reports. Nil error returns nil.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classError{err: err, fatal: false}
+}
+
+// Fatal marks given error as never worth retrying, which IsFatal reports, and retries such as WithRetry
+// return immediately by default. Nil error returns nil.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classError{err: err, fatal: true}
+}
+
+// IsRetryable reports whether given error is marked by Retryable. When marked by both, the outermost one wins.
+func IsRetryable(err error) bool {
+	var c *classError
+	return errors.As(err, &c) && !c.fatal
+}
+
+// IsFatal reports whether given error is marked by Fatal. When marked by both, the outermost one wins.
+func IsFatal(err error) bool {
+	var c *classError
+	return errors.As(err, &c) && c.fatal
+}
+
+// classError is an error marked by Retryable or Fatal, which is transparent otherwise.
+type classError struct {
+	err   error
+	fatal bool
+}
+
+// Error implements error, as same as the error marked.
+func (e *classError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error marked.
+func (e *classError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,112 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStepError_MustIdentifyDuplicatedNames(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	fail := false
+	save := Named("save", With(func() error {
+		if fail {
+			return failure
+		}
+		fail = true
+		return nil
+	}))
+	tsk := save.Then(Named("load", With(nil))).Then(save)
+
+	err := tsk.Run(context.Background())
+	assert.EqualError(t, err, "task 'save' (step 2): failure")
+	assert.ErrorIs(t, err, failure)
+
+	var stepErr *StepError
+	if assert.ErrorAs(t, err, &stepErr) {
+		assert.Equal(t, 2, stepErr.Index)
+		assert.Equal(t, "save", stepErr.Name)
+	}
+}
+
+func TestStepError_MustNotWrapAnonymousTask(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	assert.Equal(t, failure, With(func() error { return failure }).Run(context.Background()))
+}
+
+func TestStepError_MustWrapPanic(t *testing.T) {
+	t.Parallel()
+	err := RunSync(context.Background(), With(nil).Then(Named("panic", WithNoErr(func() { panic("panicked") }))))
+	assert.EqualError(t, err, "task 'panic' (step 1): panicked")
+}
+
+func TestPanicError_MustTellIndexOfPanickedStep(t *testing.T) {
+	t.Parallel()
+	err := With(nil).Then(WithNoErr(func() { panic("panicked") })).Then(With(nil)).Run(context.Background())
+	assert.EqualError(t, err, "panicked")
+
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, 1, panicErr.Index)
+		assert.Empty(t, panicErr.Name)
+		assert.Equal(t, "panicked", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "TestPanicError_MustTellIndexOfPanickedStep")
+	}
+}
+
+func TestPanicError_MustUnwrapErrorValue(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	err := RunSync(context.Background(), With(nil).Then(Named("save", With(func() error { panic(failure) }))))
+	assert.ErrorIs(t, err, failure)
+	assert.EqualError(t, err, "task 'save' (step 1): failure")
+
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, 1, panicErr.Index)
+		assert.Equal(t, "save", panicErr.Name)
+	}
+	assert.Nil(t, (&PanicError{Value: 42}).Unwrap())
+}
+
+func TestUnwrap_MustExpandJoinedErrors(t *testing.T) {
+	t.Parallel()
+	e1, e2, e3 := errors.New("e1"), errors.New("e2"), errors.New("e3")
+
+	assert.Equal(t, []error{e1, e2, e3}, Unwrap(errors.Join(e1, errors.Join(e2, nil, e3))))
+	assert.Equal(t, []error{e1, e2}, Unwrap(fmt.Errorf("wrapped: %w", errors.Join(e1, e2))))
+	assert.Equal(t, []error{e1, e2}, Unwrap(fmt.Errorf("%w and %w", e1, e2)))
+	assert.Equal(t, []error{e1}, Unwrap(e1))
+	assert.Nil(t, Unwrap(nil))
+
+	err := Named("close", With(func() error { return errors.Join(e1, e2) })).Run(context.Background())
+	assert.Equal(t, []error{e1, e2}, Unwrap(err))
+}
+
+func TestIsRetryable_IsFatal_MustClassifyWrappedErrors(t *testing.T) {
+	t.Parallel()
+	plain := errors.New("plain")
+	assert.False(t, IsRetryable(plain))
+	assert.False(t, IsFatal(plain))
+	assert.False(t, IsRetryable(nil))
+	assert.Nil(t, Retryable(nil))
+	assert.Nil(t, Fatal(nil))
+
+	retryable := fmt.Errorf("connecting: %w", Retryable(plain))
+	assert.True(t, IsRetryable(retryable))
+	assert.False(t, IsFatal(retryable))
+	assert.ErrorIs(t, retryable, plain)
+	assert.EqualError(t, retryable, "connecting: plain")
+
+	fatal := &StepError{Index: 1, Name: "auth", Err: Fatal(plain)}
+	assert.True(t, IsFatal(fatal))
+	assert.False(t, IsRetryable(fatal))
+	assert.ErrorIs(t, fatal, plain)
+
+	assert.True(t, IsFatal(Fatal(Retryable(plain))), "outermost must win")
+	assert.True(t, IsRetryable(errors.Join(plain, Retryable(plain))))
+}
@@ -0,0 +1,65 @@
+package grace
+
+import (
+	"context"
+	"time"
+)
+
+// Every returns new Task that runs given Task right away, then again on every each interval until the context
+// given to Run is done. It fails with the first error from given Task, or returns nil when the context is done.
+// Runs never overlap; a tick passed while the Task is running is dropped, thus the schedule does not drift.
+// It panics when interval is not positive.
+func Every(t Task, interval time.Duration) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	if interval <= 0 {
+		panic("grace: non-positive interval for Every")
+	}
+	node := withCtx(func(ctx context.Context) error {
+		for next := now().Now(); ; {
+			if err := t.Run(ctx); err != nil {
+				if ctx.Err() != nil && isOnlyCtxErr(ctx, err) {
+					return nil
+				}
+				return err
+			}
+			for current := now().Now(); !next.After(current); {
+				next = next.Add(interval)
+			}
+			timer, stop := now().NewTimer(next.Sub(now().Now()))
+			select {
+			case <-ctx.Done():
+				stop()
+				return nil
+			case <-timer:
+			}
+		}
+	})
+	node.await = true
+	return node
+}
+
+// isOnlyCtxErr tells whether given error tells nothing but the context is done, unlike the one joined with an error
+// of a step by withCtxErr, even if wrapped such as by StepError.
+func isOnlyCtxErr(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == ctx.Err() || err == context.Cause(ctx) {
+		return true
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if !isOnlyCtxErr(ctx, err) {
+				return false
+			}
+		}
+		return true
+	case interface{ Unwrap() error }:
+		return isOnlyCtxErr(ctx, e.Unwrap())
+	default:
+		return false
+	}
+}
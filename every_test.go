@@ -0,0 +1,76 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEvery_MustRunOnEveryInterval_UntilCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*250)
+	defer cancel()
+	count := &atomic.Int32{}
+	tsk := Every(WithNoErr(func() { count.Add(1) }), time.Millisecond*50)
+
+	start := time.Now()
+	assert.NoError(t, tsk.Run(ctx))
+	assert.Less(t, time.Since(start), time.Millisecond*400)
+	assert.InDelta(t, 6, count.Load(), 2)
+}
+
+func TestEvery_MustFail_WithFirstError(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	count := &atomic.Int32{}
+	tsk := Every(With(func() error {
+		if count.Add(1) == 3 {
+			return failure
+		}
+		return nil
+	}), time.Millisecond)
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, int32(3), count.Load())
+}
+
+func TestEvery_MustReturnNil_WhenCanceledInRun(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk := Every(WithCtx(func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}), time.Minute)
+
+	assert.NoError(t, RunSync(ctx, tsk))
+}
+
+func TestEvery_MustFail_WhenFailedAsCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	failure := errors.New("real")
+	tsk := Every(With(func() error {
+		cancel()
+		return failure
+	}), time.Minute)
+
+	err := RunSync(ctx, tsk)
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	tsk = Every(Named("wait", WithCtx(func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})), time.Minute)
+	assert.NoError(t, RunSync(ctx, tsk), "must return nil for the context error wrapped by StepError")
+}
+
+func TestEvery_MustPanic_WhenIntervalNotPositive(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { Every(With(nil), 0) })
+}
@@ -0,0 +1,55 @@
+// Package gracegrpc adapts gRPC servers into grace.Task.
+// It depends on no gRPC package, as *grpc.Server satisfies Stopper as is.
+package gracegrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/state303/grace"
+)
+
+// ErrHardStop is returned when a server has been stopped forcibly, as GracefulStop has not returned in time.
+var ErrHardStop = errors.New("grpc server stopped forcibly")
+
+// Stopper is a gRPC server, such as *grpc.Server.
+type Stopper interface {
+	// GracefulStop stops the server from accepting new connections and RPCs, then waits for pending RPCs to finish.
+	GracefulStop()
+	// Stop closes every each connection and listener, canceling pending RPCs.
+	Stop()
+}
+
+// GracefulStop returns a Task that stops the server by GracefulStop, but falls back to Stop when the timeout elapses
+// or the context given to Run is done, returning an error wrapping ErrHardStop. Non-positive timeout never elapses.
+// Thus, HTTP and gRPC servers are shut down alike, by tasks chained with Then.
+func GracefulStop(s Stopper, timeout time.Duration) grace.Task {
+	return grace.WithCtx(func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.GracefulStop()
+		}()
+
+		var elapsed <-chan time.Time
+		if timeout > 0 {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			elapsed = timer.C
+		}
+		var err error
+		select {
+		case <-done:
+			return nil
+		case <-elapsed:
+			err = fmt.Errorf("%w after %v", ErrHardStop, timeout)
+		case <-ctx.Done():
+			err = fmt.Errorf("%w: %w", ErrHardStop, context.Cause(ctx))
+		}
+		s.Stop()
+		<-done // GracefulStop returns once Stop is called
+		return err
+	})
+}
@@ -0,0 +1,62 @@
+package gracegrpc
+
+import (
+	"context"
+	"github.com/state303/grace"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// server is a Stopper, which GracefulStop takes the duration of pending RPCs.
+type server struct {
+	pending time.Duration
+	stop    chan struct{}
+	stopped atomic.Bool
+}
+
+func newServer(pending time.Duration) *server {
+	return &server{pending: pending, stop: make(chan struct{})}
+}
+
+func (s *server) GracefulStop() {
+	select {
+	case <-time.After(s.pending):
+	case <-s.stop:
+	}
+}
+
+func (s *server) Stop() {
+	s.stopped.Store(true)
+	close(s.stop)
+}
+
+func TestGracefulStop_MustStopGracefully_WhenInTime(t *testing.T) {
+	t.Parallel()
+	s := newServer(time.Millisecond * 10)
+	assert.NoError(t, GracefulStop(s, time.Second).Run(context.Background()))
+	assert.False(t, s.stopped.Load())
+}
+
+func TestGracefulStop_MustStopForcibly_WhenTimeoutElapsed(t *testing.T) {
+	t.Parallel()
+	s := newServer(time.Hour)
+	start := time.Now()
+	err := GracefulStop(s, time.Millisecond*20).Run(context.Background())
+	assert.ErrorIs(t, err, ErrHardStop)
+	assert.EqualError(t, err, "grpc server stopped forcibly after 20ms")
+	assert.True(t, s.stopped.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGracefulStop_MustStopForcibly_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	s := newServer(time.Hour)
+	err := grace.RunSync(ctx, GracefulStop(s, 0))
+	assert.ErrorIs(t, err, ErrHardStop)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, s.stopped.Load())
+}
@@ -0,0 +1,57 @@
+// Package gracesql integrates database/sql transactions with grace.Task.
+package gracesql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/state303/grace"
+)
+
+var (
+	// ErrNilDB is returned from the Task built by Tx with nil *sql.DB.
+	ErrNilDB = errors.New("gracesql: nil db")
+	// ErrNilFunc is returned from the Task built by Tx with nil function.
+	ErrNilFunc = errors.New("gracesql: nil transaction function")
+)
+
+// Tx returns a Task that begins a transaction with the context given to Run, then runs fn with it. The transaction
+// is committed when fn returns nil, or rolled back when fn fails, panics, or the context is done in the meantime.
+// An error from Rollback is joined with the error that has caused it, while a panic is rolled back then surfaced
+// as the run recovers panics from steps. Nil db or fn is reported at once, by ErrNilDB or ErrNilFunc from the Task.
+func Tx(db *sql.DB, fn func(tx *sql.Tx) error) grace.Task {
+	switch {
+	case db == nil:
+		return grace.With(func() error { return ErrNilDB })
+	case fn == nil:
+		return grace.With(func() error { return ErrNilFunc })
+	}
+	return grace.WithCtx(func(ctx context.Context) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if p := recover(); p != nil {
+				_ = tx.Rollback()
+				panic(p)
+			}
+		}()
+		if err := fn(tx); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+		return tx.Commit()
+	})
+}
+
+// rollback rolls back the transaction, ignoring the error of the transaction already rolled back by its context.
+func rollback(tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package gracesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/state303/grace"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a driver.Driver which records transactions by the name of connections.
+type fakeDriver struct {
+	mu   sync.Mutex
+	logs map[string]*fakeLog
+}
+
+// fakeLog records what happened to transactions.
+type fakeLog struct {
+	mu          sync.Mutex
+	events      []string
+	rollbackErr error
+}
+
+func (l *fakeLog) add(event string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	if event == "rollback" {
+		return l.rollbackErr
+	}
+	return nil
+}
+
+func (l *fakeLog) Events() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.events...)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &fakeConn{log: d.logs[name]}, nil
+}
+
+type fakeConn struct {
+	log *fakeLog
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return c, c.log.add("begin") }
+func (c *fakeConn) Commit() error                       { return c.log.add("commit") }
+func (c *fakeConn) Rollback() error                     { return c.log.add("rollback") }
+
+var (
+	drv = &fakeDriver{logs: map[string]*fakeLog{}}
+	dbs atomic.Int32
+)
+
+func init() {
+	sql.Register("gracesql-fake", drv)
+}
+
+// openDB returns new *sql.DB of the fake driver, along with its log.
+func openDB(t *testing.T) (*sql.DB, *fakeLog) {
+	name := strconv.Itoa(int(dbs.Add(1)))
+	log := &fakeLog{}
+	drv.mu.Lock()
+	drv.logs[name] = log
+	drv.mu.Unlock()
+	db, err := sql.Open("gracesql-fake", name)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, log
+}
+
+func TestTx_MustCommit_WhenSucceeded(t *testing.T) {
+	t.Parallel()
+	db, log := openDB(t)
+	called := false
+	assert.NoError(t, Tx(db, func(tx *sql.Tx) error {
+		called = tx != nil
+		return nil
+	}).Run(context.Background()))
+	assert.True(t, called)
+	assert.Equal(t, []string{"begin", "commit"}, log.Events())
+}
+
+func TestTx_MustRollback_WhenFailed(t *testing.T) {
+	t.Parallel()
+	db, log := openDB(t)
+	failure, errRollback := errors.New("failure"), errors.New("rollback failed")
+	log.rollbackErr = errRollback
+
+	err := Tx(db, func(*sql.Tx) error { return failure }).Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorIs(t, err, errRollback)
+	assert.Equal(t, []string{"begin", "rollback"}, log.Events())
+}
+
+func TestTx_MustRollback_WhenPanicked(t *testing.T) {
+	t.Parallel()
+	db, log := openDB(t)
+	err := Tx(db, func(*sql.Tx) error { panic("panicked") }).Run(context.Background())
+	var panicErr *grace.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, []string{"begin", "rollback"}, log.Events())
+}
+
+func TestTx_MustRollback_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	db, log := openDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := grace.RunSync(ctx, Tx(db, func(*sql.Tx) error {
+		cancel()
+		return nil
+	}))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, log.Events(), "commit")
+	assert.Eventually(t, func() bool { // may be rolled back by database/sql on its own
+		events := log.Events()
+		return len(events) == 2 && events[1] == "rollback"
+	}, time.Second, time.Millisecond)
+}
+
+func TestTx_MustFail_WhenNilArguments(t *testing.T) {
+	t.Parallel()
+	db, _ := openDB(t)
+	assert.ErrorIs(t, Tx(nil, func(*sql.Tx) error { return nil }).Run(context.Background()), ErrNilDB)
+	assert.ErrorIs(t, Tx(db, nil).Run(context.Background()), ErrNilFunc)
+}
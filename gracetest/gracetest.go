@@ -0,0 +1,136 @@
+// Package gracetest provides tasks and assertions for testing code that builds or runs grace.Task.
+package gracetest
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/state303/grace"
+)
+
+// Call is an invocation of a task created by Recorder.
+type Call struct {
+	// Name given to Recorder.Task
+	Name string
+	// At is the time when the task was invoked.
+	At time.Time
+}
+
+// Recorder records invocations of tasks created from it, in order of their invocations.
+// The zero value is ready to use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Task returns a named grace.Task that records an invocation by the name on every each run.
+func (r *Recorder) Task(name string) grace.Task {
+	return grace.Named(name, grace.WithNoErr(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.calls = append(r.calls, Call{Name: name, At: time.Now()})
+	}))
+}
+
+// Calls returns a copy of recorded invocations.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// Names returns names of recorded invocations.
+func (r *Recorder) Names() []string {
+	calls := r.Calls()
+	names := make([]string, len(calls))
+	for i, c := range calls {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Failing returns a grace.Task that always fails with given error.
+func Failing(err error) grace.Task {
+	if err == nil {
+		err = errors.New("gracetest: failing task")
+	}
+	return grace.With(func() error { return err })
+}
+
+// Blocker holds tasks created from it until released.
+type Blocker struct {
+	started  chan struct{}
+	released chan struct{}
+	start    sync.Once
+	release  sync.Once
+}
+
+// Blocking returns new Blocker.
+func Blocking() *Blocker {
+	return &Blocker{started: make(chan struct{}), released: make(chan struct{})}
+}
+
+// Task returns a grace.Task that blocks until the Blocker is released.
+// As the step is not aware of the context, it keeps blocking even after Run returned by the context,
+// hence the Blocker must be released at the end of the test.
+func (b *Blocker) Task() grace.Task {
+	return grace.WithNoErr(func() {
+		b.start.Do(func() { close(b.started) })
+		<-b.released
+	})
+}
+
+// Started returns a channel that is closed when any task from the Blocker has started.
+func (b *Blocker) Started() <-chan struct{} {
+	return b.started
+}
+
+// Release lets every each task from the Blocker proceed, including those to be run later.
+func (b *Blocker) Release() {
+	b.release.Do(func() { close(b.released) })
+}
+
+// TestingT is a subset of testing.TB used by assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertOrder asserts that the Recorder has recorded invocations of exactly given names, in that order.
+func AssertOrder(t TestingT, r *Recorder, names ...string) bool {
+	t.Helper()
+	actual := r.Names()
+	if len(actual) != len(names) {
+		t.Errorf("expected invocations %q, but got %q", names, actual)
+		return false
+	}
+	for i := range names {
+		if actual[i] != names[i] {
+			t.Errorf("expected invocations %q, but got %q (differs at %d)", names, actual, i)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNotCalled asserts that the Recorder has recorded no invocation of given names.
+func AssertNotCalled(t TestingT, r *Recorder, names ...string) bool {
+	t.Helper()
+	for _, c := range r.Calls() {
+		for _, name := range names {
+			if c.Name == name {
+				t.Errorf("expected %q not to be invoked, but invoked at %s", name, c.At.Format(time.RFC3339Nano))
+				return false
+			}
+		}
+	}
+	return true
+}
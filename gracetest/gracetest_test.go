@@ -0,0 +1,79 @@
+package gracetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/state303/grace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// mockT records failures of assertions.
+type mockT struct {
+	errors []string
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder_MustRecordOrderly(t *testing.T) {
+	t.Parallel()
+	rec := NewRecorder()
+	tsk := rec.Task("a").Then(rec.Task("b")).Then(rec.Task("c"))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	AssertOrder(t, rec, "a", "b", "c")
+
+	calls := rec.Calls()
+	assert.Len(t, calls, 3)
+	assert.False(t, calls[1].At.Before(calls[0].At))
+	assert.Equal(t, "a -> b -> c (3 steps)", grace.Describe(tsk))
+}
+
+func TestFailing_MustStopChain(t *testing.T) {
+	t.Parallel()
+	rec, failure := &Recorder{}, errors.New("failure")
+	tsk := rec.Task("a").Then(Failing(failure)).Then(rec.Task("b"))
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	AssertOrder(t, rec, "a")
+	AssertNotCalled(t, rec, "b")
+	assert.Error(t, Failing(nil).Run(context.Background()))
+}
+
+func TestBlocking_MustHoldUntilReleased(t *testing.T) {
+	t.Parallel()
+	rec, blocker := NewRecorder(), Blocking()
+	tsk := grace.All(blocker.Task().Then(rec.Task("blocked")), rec.Task("free"))
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- tsk.Run(context.Background()) }()
+
+	<-blocker.Started()
+	assert.Eventually(t, func() bool { return len(rec.Calls()) == 1 }, time.Second, time.Millisecond)
+	AssertOrder(t, rec, "free")
+
+	blocker.Release()
+	blocker.Release()
+	assert.NoError(t, <-errChan)
+	AssertOrder(t, rec, "free", "blocked")
+}
+
+func TestAssertOrder_MustReportMismatch(t *testing.T) {
+	t.Parallel()
+	rec := NewRecorder()
+	assert.NoError(t, rec.Task("a").Then(rec.Task("b")).Run(context.Background()))
+
+	m := &mockT{}
+	assert.False(t, AssertOrder(m, rec, "b", "a"))
+	assert.False(t, AssertOrder(m, rec, "a"))
+	assert.False(t, AssertNotCalled(m, rec, "b"))
+	assert.True(t, AssertOrder(m, rec, "a", "b"))
+	assert.Len(t, m.errors, 3)
+	assert.Contains(t, m.errors[0], "differs at 0")
+}
@@ -0,0 +1,48 @@
+// Package gracetrace integrates grace.Task with OpenTelemetry tracing.
+package gracetrace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/state303/grace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace returns a copy of given chain, which opens a span for every each step from the context given to Run.
+// Spans are named after the names of tasks, or their indices when not named, and carry tags of tasks as attributes
+// prefixed by "grace.tag.".
+// Errors, including panics, are recorded on the span along with the error status; joined errors are recorded one by one.
+func Trace(t grace.Task, tracer trace.Tracer) grace.Task {
+	return grace.Intercept(t, func(info grace.StepInfo, step grace.StepCtx) grace.StepCtx {
+		name := info.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", info.Index)
+		}
+		attrs := []attribute.KeyValue{attribute.Int("grace.step.index", info.Index)}
+		for k, v := range info.Tags {
+			attrs = append(attrs, attribute.String("grace.tag."+k, v))
+		}
+		return func(ctx context.Context) (err error) {
+			ctx, span := tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+			defer span.End()
+			defer func() {
+				if p := recover(); p != nil {
+					span.SetStatus(codes.Error, fmt.Sprint(p))
+					panic(p)
+				}
+			}()
+			if err = step(ctx); err != nil {
+				for _, e := range grace.Unwrap(err) {
+					span.RecordError(e)
+				}
+				span.SetStatus(codes.Error, err.Error())
+				return err
+			}
+			span.SetStatus(codes.Ok, "")
+			return nil
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package gracetrace
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/grace"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestTrace_MustRecordSpanPerStep(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+
+	var stepSpan trace.SpanContext
+	tsk := grace.Named("stop-http", grace.With(nil)).
+		Then(grace.WithCtx(func(ctx context.Context) error {
+			stepSpan = trace.SpanContextFromContext(ctx)
+			return nil
+		})).
+		Then(grace.WithTags(grace.Named("close-db", grace.With(func() error { return errors.New("failure") })), map[string]string{"owner": "storage"}))
+
+	assert.ErrorContains(t, Trace(tsk, tracer).Run(ctx), "failure")
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 4)
+	assert.Equal(t, "stop-http", spans[0].Name())
+	assert.Equal(t, codes.Ok, spans[0].Status().Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("grace.step.index", 0))
+
+	assert.Equal(t, "step 1", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), stepSpan.SpanID(), "step must receive the context of the span")
+
+	assert.Equal(t, "close-db", spans[2].Name())
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, "failure", spans[2].Status().Description)
+	assert.Len(t, spans[2].Events(), 1, "error must be recorded")
+	assert.Contains(t, spans[2].Attributes(), attribute.String("grace.tag.owner", "storage"))
+}
+
+func TestTrace_MustRecordPanic(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	tsk := Trace(grace.WithNoErr(func() { panic("panicked") }), tracer)
+
+	assert.ErrorContains(t, tsk.Run(context.Background()), "panicked")
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "panicked", spans[0].Status().Description)
+}
+
+func TestTrace_MustRecordJoinedErrors_OneByOne(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	tsk := Trace(grace.With(func() error { return errors.Join(errors.New("e1"), errors.New("e2")) }), tracer)
+
+	assert.Error(t, tsk.Run(context.Background()))
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events(), 2)
+}
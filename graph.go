@@ -0,0 +1,175 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Graph builds a Task from named tasks with their dependencies, which runs every each of tasks
+// right after all of its dependencies succeeded. Thus, independent tasks run concurrently.
+// The zero value is ready to use.
+type Graph struct {
+	nodes map[string]*graphNode
+	order []string // names in order of addition, keeping everything deterministic
+	err   error    // first error observed while adding tasks
+}
+
+type graphNode struct {
+	task  Task
+	after []string
+}
+
+// Dependency declares tasks that must succeed before a task added to Graph.
+type Dependency struct {
+	names []string
+}
+
+// After returns Dependency on tasks of given names.
+func After(names ...string) Dependency {
+	return Dependency{names: names}
+}
+
+// Add adds the task by the name, which runs after the tasks of given dependencies.
+// Names must be unique in the Graph; violation is reported by Graph.Task.
+func (g *Graph) Add(name string, t Task, deps ...Dependency) *Graph {
+	if g.nodes == nil {
+		g.nodes = make(map[string]*graphNode)
+	}
+	if _, exists := g.nodes[name]; exists {
+		if g.err == nil {
+			g.err = fmt.Errorf("duplicated task %q in graph", name)
+		}
+		return g
+	}
+	if isNil(t) {
+		t = With(nil)
+	}
+	node := &graphNode{task: t}
+	for _, d := range deps {
+		node.after = append(node.after, d.names...)
+	}
+	g.nodes[name] = node
+	g.order = append(g.order, name)
+	return g
+}
+
+// Task validates the Graph and returns a Task running it.
+// Dependency on unknown task or cycle between tasks is reported as an error.
+//
+// When a task fails, tasks depending on it are skipped, while other tasks keep running.
+// Errors from every failed task are joined and returned.
+func (g *Graph) Task() (Task, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	for _, name := range g.order {
+		for _, dep := range g.nodes[name].after {
+			if _, ok := g.nodes[dep]; !ok {
+				return nil, fmt.Errorf("task %q depends on unknown task %q", name, dep)
+			}
+		}
+	}
+	if cycle := g.cycle(); cycle != nil {
+		return nil, fmt.Errorf("cycle in graph: %s", strings.Join(cycle, " -> "))
+	}
+
+	// copy the current state, so that further additions do not affect the Task
+	nodes, order := make(map[string]*graphNode, len(g.nodes)), make([]string, len(g.order))
+	for name, node := range g.nodes {
+		nodes[name] = node
+	}
+	copy(order, g.order)
+
+	t := withCtx(func(ctx context.Context) error {
+		return runGraph(ctx, nodes, order)
+	})
+	t.await = true // every task is run with given context, hence returns promptly on cancellation
+	return t, nil
+}
+
+// cycle returns names of tasks forming a cycle, where the first one is repeated at the end, or nil if none.
+func (g *Graph) cycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state, path := make(map[string]int, len(g.nodes)), make([]string, 0)
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range g.nodes[name].after {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, name := range g.order {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// runGraph runs tasks as soon as their dependencies succeeded, until no more task can be run.
+func runGraph(ctx context.Context, nodes map[string]*graphNode, order []string) error {
+	type result struct {
+		name string
+		err  error
+	}
+	pending, dependents := make(map[string]int, len(nodes)), make(map[string][]string, len(nodes))
+	for _, name := range order {
+		for _, dep := range nodes[name].after {
+			pending[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	results, running := make(chan result, len(nodes)), 0
+	start := func(name string) {
+		running++
+		go func() { results <- result{name, nodes[name].task.Run(ctx)} }()
+	}
+	for _, name := range order {
+		if pending[name] == 0 {
+			start(name)
+		}
+	}
+
+	var errs []error
+	for ; running > 0; running-- {
+		r := <-results
+		if r.err != nil {
+			if !isContextErr(r.err) || ctx.Err() == nil {
+				errs = append(errs, fmt.Errorf("task %q: %w", r.name, r.err))
+			}
+			continue // dependents never start, as the pending count never reaches zero
+		}
+		for _, dep := range dependents[r.name] {
+			if pending[dep]--; pending[dep] == 0 && ctx.Err() == nil {
+				start(dep)
+			}
+		}
+	}
+	if err := ctxErr(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
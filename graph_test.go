@@ -0,0 +1,112 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGraph_MustRunInOrderOfDependencies(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[string]()
+	g := &Graph{}
+	g.Add("close-db", With(store(valueOf("close-db"))), After("drain-http", "stop-workers")).
+		Add("drain-http", With(store(valueOf("drain-http"))), After("stop-intake")).
+		Add("stop-workers", With(store(valueOf("stop-workers"))), After("stop-intake")).
+		Add("stop-intake", With(store(valueOf("stop-intake"))))
+
+	tsk, err := g.Task()
+	assert.NoError(t, err)
+	assert.NoError(t, tsk.Run(context.Background()))
+
+	values := sink.Values()
+	assert.Len(t, values, 4)
+	assert.Equal(t, "stop-intake", values[0])
+	assert.ElementsMatch(t, []string{"drain-http", "stop-workers"}, values[1:3])
+	assert.Equal(t, "close-db", values[3])
+}
+
+func TestGraph_MustRunIndependentTasksConcurrently(t *testing.T) {
+	t.Parallel()
+	a, b := make(chan struct{}), make(chan struct{})
+	wait := func(own, other chan struct{}) Task {
+		return With(func() error {
+			close(own)
+			select {
+			case <-other:
+				return nil
+			case <-time.After(time.Second * 5):
+				return errors.New("not concurrent")
+			}
+		})
+	}
+	tsk, err := (&Graph{}).Add("a", wait(a, b)).Add("b", wait(b, a)).Task()
+	assert.NoError(t, err)
+	assert.NoError(t, tsk.Run(context.Background()))
+}
+
+func TestGraph_MustSkipDependents_WhenTaskFails(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[string]()
+	failure := errors.New("failure")
+	g := &Graph{}
+	g.Add("fail", With(func() error { return failure })).
+		Add("dependent", With(store(valueOf("dependent"))), After("fail")).
+		Add("transitive", With(store(valueOf("transitive"))), After("dependent")).
+		Add("unrelated", With(store(valueOf("unrelated")))).
+		Add("after-unrelated", With(store(valueOf("after-unrelated"))), After("unrelated"))
+
+	tsk, err := g.Task()
+	assert.NoError(t, err)
+	err = tsk.Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorContains(t, err, `task "fail"`)
+	assert.Equal(t, []string{"unrelated", "after-unrelated"}, sink.Values())
+}
+
+func TestGraph_Task_MustRejectCycle(t *testing.T) {
+	t.Parallel()
+	g := &Graph{}
+	g.Add("a", nil).
+		Add("b", nil, After("a", "d")).
+		Add("c", nil, After("b")).
+		Add("d", nil, After("c"))
+
+	tsk, err := g.Task()
+	assert.Nil(t, tsk)
+	assert.EqualError(t, err, "cycle in graph: b -> d -> c -> b")
+}
+
+func TestGraph_Task_MustRejectInvalidDefinitions(t *testing.T) {
+	t.Parallel()
+	_, err := (&Graph{}).Add("a", nil, After("unknown")).Task()
+	assert.EqualError(t, err, `task "a" depends on unknown task "unknown"`)
+
+	_, err = (&Graph{}).Add("a", nil).Add("a", nil).Task()
+	assert.EqualError(t, err, `duplicated task "a" in graph`)
+
+	_, err = (&Graph{}).Add("a", nil, After("a")).Task()
+	assert.EqualError(t, err, "cycle in graph: a -> a")
+}
+
+func TestGraph_MustNotStartTasks_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	sink, store := Collect[string]()
+	g := &Graph{}
+	g.Add("cancel", WithNoErr(cancel)).Add("after", With(store(valueOf("after"))), After("cancel"))
+
+	tsk, err := g.Task()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, tsk.Run(ctx), context.Canceled)
+	assert.Empty(t, sink.Values())
+}
+
+func TestGraph_MustRunNothing_WhenEmpty(t *testing.T) {
+	t.Parallel()
+	tsk, err := (&Graph{}).Task()
+	assert.NoError(t, err)
+	assert.NoError(t, tsk.Run(context.Background()))
+}
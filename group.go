@@ -0,0 +1,108 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrShutdown is the cause of the context of chains canceled by Group.Shutdown.
+var ErrShutdown = errors.New("group shutdown")
+
+// Group tracks chains running in background, to shut them down altogether.
+// Zero value of Group is ready to use.
+type Group struct {
+	mu       sync.Mutex
+	runs     map[*groupRun]struct{} // in progress, removed once finished
+	errs     []error                // of chains finished, kept until Shutdown up to groupErrLimit
+	omitted  int                    // number of errors of chains finished beyond groupErrLimit
+	shutdown bool
+}
+
+// groupErrLimit is the number of errors of chains finished which Group keeps until Shutdown, so that a long-lived
+// Group of failing chains never grows without bound. Those beyond are counted alone.
+const groupErrLimit = 64
+
+// groupRun is a chain run by Group.
+type groupRun struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+	err    error
+}
+
+// Go runs given Task in background with the context, tracking it until Shutdown.
+// Unlike Task.Run, the step in progress is never abandoned on cancellation, so that Shutdown can wait for it.
+// When the Group has been shut down, the Task never runs.
+func (g *Group) Go(ctx context.Context, t Task) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	r := &groupRun{cancel: cancel, done: make(chan struct{})}
+	g.mu.Lock()
+	if g.shutdown {
+		cancel(ErrShutdown)
+	}
+	if g.runs == nil {
+		g.runs = map[*groupRun]struct{}{}
+	}
+	g.runs[r] = struct{}{}
+	g.mu.Unlock()
+
+	go func() {
+		defer close(r.done)
+		defer g.finish(r)
+		defer cancel(nil)
+		// walk rather than RunSync, so that a step error is never joined with the context error of shutdown
+		err := walk(ctx, t, nil, nil, nil)
+		if errors.Is(err, context.Canceled) && errors.Is(context.Cause(ctx), ErrShutdown) {
+			err = nil // stopped by shutdown as requested
+		}
+		r.err = err
+	}()
+}
+
+// finish stops tracking given run, keeping its error if any.
+func (g *Group) finish(r *groupRun) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.runs, r)
+	switch {
+	case r.err == nil:
+	case len(g.errs) < groupErrLimit:
+		g.errs = append(g.errs, r.err)
+	default:
+		g.omitted++
+	}
+}
+
+// Shutdown cancels every each chain of the Group with ErrShutdown as the cause, then waits for their steps
+// in progress to finish. It returns the errors from the chains joined, including those finished already,
+// except those of the cancellation. Of chains finished already, only the first 64 errors are kept, and the rest are
+// told by their number. When the context is done before the chains finish, the error of the context is joined as well.
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.shutdown = true
+	runs := make([]*groupRun, 0, len(g.runs))
+	for r := range g.runs {
+		runs = append(runs, r)
+	}
+	errs := append([]error(nil), g.errs...)
+	if g.omitted > 0 {
+		errs = append(errs, fmt.Errorf("%d more errors of chains omitted", g.omitted))
+	}
+	g.mu.Unlock()
+
+	for _, r := range runs {
+		r.cancel(ErrShutdown)
+	}
+	for _, r := range runs {
+		select {
+		case <-r.done:
+			if r.err != nil {
+				errs = append(errs, r.err)
+			}
+		case <-ctx.Done():
+			return errors.Join(append(errs, ctxErr(ctx))...)
+		}
+	}
+	return errors.Join(errs...)
+}
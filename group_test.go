@@ -0,0 +1,126 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_Shutdown_MustWaitForStepsInProgress(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	started, finished, next := &atomic.Int32{}, &atomic.Int32{}, &atomic.Int32{}
+	for i := 0; i < 5; i++ {
+		g.Go(context.Background(), WithNoErr(func() {
+			started.Add(1)
+			time.Sleep(time.Millisecond * 50)
+			finished.Add(1)
+		}).Then(WithNoErr(func() { next.Add(1) })))
+	}
+	for started.Load() < 5 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	assert.NoError(t, g.Shutdown(ctx))
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+	assert.Equal(t, int32(5), finished.Load())
+	assert.Zero(t, next.Load())
+}
+
+func TestGroup_Shutdown_MustJoinErrors(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	failure := errors.New("failure")
+	failed, waiting := make(chan struct{}), make(chan struct{})
+	g.Go(context.Background(), With(func() error {
+		close(failed)
+		return failure
+	}))
+	g.Go(context.Background(), WithCtx(func(ctx context.Context) error {
+		close(waiting)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	<-failed
+	<-waiting
+
+	err := g.Shutdown(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestGroup_Shutdown_MustReturn_WhenDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	g.Go(context.Background(), WithNoErr(func() {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	start := time.Now()
+	assert.ErrorIs(t, g.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+}
+
+func TestGroup_Go_MustNotRun_AfterShutdown(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	assert.NoError(t, g.Shutdown(context.Background()))
+
+	called := &atomic.Bool{}
+	g.Go(context.Background(), WithNoErr(func() { called.Store(true) }))
+	assert.NoError(t, g.Shutdown(context.Background()))
+	assert.False(t, called.Load())
+}
+
+func TestGroup_Go_MustForgetFinishedChains(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	failure := errors.New("failure")
+	for i := 0; i < 100; i++ {
+		g.Go(context.Background(), With(nil))
+	}
+	g.Go(context.Background(), With(func() error { return failure }))
+
+	assert.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return len(g.runs) == 0
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, g.Shutdown(context.Background()), failure, "must keep errors of chains finished")
+}
+
+func TestGroup_Go_MustBoundErrorsKept(t *testing.T) {
+	t.Parallel()
+	g := &Group{}
+	failure := errors.New("failure")
+	for i := 0; i < groupErrLimit*10; i++ {
+		g.Go(context.Background(), With(func() error { return failure }))
+	}
+
+	assert.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return len(g.runs) == 0
+	}, time.Second, time.Millisecond)
+	g.mu.Lock()
+	assert.Len(t, g.errs, groupErrLimit)
+	assert.Equal(t, groupErrLimit*9, g.omitted)
+	g.mu.Unlock()
+
+	err := g.Shutdown(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorContains(t, err, fmt.Sprintf("%d more errors of chains omitted", groupErrLimit*9))
+}
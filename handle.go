@@ -0,0 +1,118 @@
+package grace
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Handle controls a chain running in background, started by Start.
+type Handle struct {
+	done   chan struct{}
+	err    error
+	report Report
+	status Status // final one, once done
+	pause  *pauser
+	rec    *recorder
+	steps  int
+}
+
+// Start runs given Task in background as same as Task.Run does, and returns Handle to control the run.
+func Start(ctx context.Context, t Task) *Handle {
+	return (&Runner{}).Start(ctx, t)
+}
+
+// Done returns a channel that is closed when the run returns.
+func (h *Handle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait waits for the run to return, then returns its error.
+func (h *Handle) Wait() error {
+	<-h.done
+	return h.err
+}
+
+// Report waits for the run to return, then returns Report of the run.
+func (h *Handle) Report() Report {
+	<-h.done
+	return h.report
+}
+
+// Status returns Status of the run at the moment. It is safe to call from any goroutine.
+// Once the run has returned, it returns the same Status consistent with the error returned.
+func (h *Handle) Status() Status {
+	select {
+	case <-h.done:
+		return h.status
+	default:
+	}
+	return h.current()
+}
+
+// current returns Status of the run in progress.
+func (h *Handle) current() Status {
+	index, name, ok := h.rec.current()
+	if !ok {
+		return Status{State: StatePending, Steps: h.steps}
+	}
+	return Status{State: StateRunning, Index: index, Name: name, Steps: h.steps}
+}
+
+// Pause holds the run at the next boundary of steps, never in the middle of a step, until Resume is called.
+// The context given to Start is still honored while paused, which terminates the run.
+// The time paused is reported as StepReport.Paused of the step that follows.
+func (h *Handle) Pause() {
+	h.pause.pause()
+}
+
+// Resume lets the run paused by Pause continue. It does nothing if not paused.
+func (h *Handle) Resume() {
+	h.pause.resume()
+}
+
+// pauser holds a run at the boundary of steps while paused. Nil pauser never pauses.
+type pauser struct {
+	mu      sync.Mutex
+	resumed chan struct{} // present while paused, closed on resume
+}
+
+// pause makes following calls of wait hold until resume.
+func (p *pauser) pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+	}
+}
+
+// resume releases every each wait in progress.
+func (p *pauser) resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+	}
+}
+
+// wait holds until resumed if paused, then returns how long it has been held.
+// It fails with the error of the context when the context is done before resumed.
+func (p *pauser) wait(ctx context.Context) (time.Duration, error) {
+	if p == nil {
+		return 0, nil
+	}
+	p.mu.Lock()
+	resumed := p.resumed
+	p.mu.Unlock()
+	if resumed == nil {
+		return 0, nil
+	}
+	start := time.Now()
+	select {
+	case <-resumed:
+		return time.Since(start), nil
+	case <-ctx.Done():
+		return time.Since(start), ctxErr(ctx)
+	}
+}
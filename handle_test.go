@@ -0,0 +1,87 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStart_MustRunInBackground(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	h := Start(context.Background(), With(nil).Then(With(func() error { return failure })))
+
+	<-h.Done()
+	assert.ErrorIs(t, h.Wait(), failure)
+	report := h.Report()
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+}
+
+func TestHandle_Pause_MustHoldAtNextStepBoundary(t *testing.T) {
+	t.Parallel()
+	started, release := make(chan struct{}), make(chan struct{})
+	completed, next := &atomic.Bool{}, &atomic.Bool{}
+	tsk := WithNoErr(func() {
+		close(started)
+		<-release
+		completed.Store(true)
+	}).Then(WithNoErr(func() { next.Store(true) }))
+
+	h := Start(context.Background(), tsk)
+	<-started
+	h.Pause()
+	close(release)
+
+	time.Sleep(time.Millisecond * 50)
+	assert.True(t, completed.Load(), "must not pause in the middle of a step")
+	assert.False(t, next.Load())
+
+	h.Resume()
+	assert.NoError(t, h.Wait())
+	assert.True(t, next.Load())
+
+	report := h.Report()
+	assert.Zero(t, report.Steps[0].Paused)
+	assert.GreaterOrEqual(t, report.Steps[1].Paused, time.Millisecond*50)
+	assert.Less(t, report.Steps[1].Duration, time.Millisecond*50)
+}
+
+func TestHandle_Pause_MustHonorContext(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	started, release := make(chan struct{}), make(chan struct{})
+	next := &atomic.Bool{}
+	tsk := WithNoErr(func() {
+		close(started)
+		<-release
+	}).Then(WithNoErr(func() { next.Store(true) }))
+
+	h := Start(ctx, tsk)
+	<-started
+	h.Pause()
+	close(release)
+	time.Sleep(time.Millisecond * 20)
+	cancel()
+
+	select {
+	case <-h.Done():
+	case <-time.After(time.Second):
+		t.Fatal("must terminate while paused")
+	}
+	assert.ErrorIs(t, h.Wait(), context.Canceled)
+	assert.False(t, next.Load())
+	assert.Equal(t, StepNotReached, h.Report().Steps[1].Status)
+}
+
+func TestHandle_Resume_MustDoNothing_WhenNotPaused(t *testing.T) {
+	t.Parallel()
+	h := Start(context.Background(), With(nil))
+	h.Resume()
+	h.Pause()
+	h.Resume()
+	assert.NoError(t, h.Wait())
+}
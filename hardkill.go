@@ -0,0 +1,54 @@
+package grace
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrGracePeriod is the error of the task left running in the Report given to the callback of WithHardKill.
+var ErrGracePeriod = errors.New("grace period exceeded")
+
+// exit terminates the process, replaced by tests.
+var exit = os.Exit
+
+// WithHardKill returns RunOption that terminates the process by os.Exit with the code, when a run has not returned
+// within the grace period, such as a shutdown stuck by Close which never returns. Before the exit, onTimeout is called
+// with Report of the run at the moment, where the task left running is failed with ErrGracePeriod.
+// A panic from onTimeout is recovered, so that the process exits anyway. Non-positive grace period is ignored.
+func WithHardKill(gracePeriod time.Duration, code int, onTimeout func(report Report)) RunOption {
+	return func(r *Runner) {
+		if gracePeriod > 0 {
+			r.hardKill = &hardKill{period: gracePeriod, code: code, onTimeout: onTimeout}
+		}
+	}
+}
+
+// hardKill terminates the process when a run has not returned within its period.
+type hardKill struct {
+	period    time.Duration
+	code      int
+	onTimeout func(report Report)
+}
+
+// watch watches the run of given chain recorded by the recorder, until the returned function is called.
+func (k *hardKill) watch(t Task, rec *recorder) (stop func()) {
+	if k == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	timer, stopTimer := now().NewTimer(k.period)
+	go func() {
+		defer stopTimer()
+		select {
+		case <-done:
+		case <-timer:
+			report := rec.report(t, ErrGracePeriod)
+			if k.onTimeout != nil {
+				_ = guard(func() error { k.onTimeout(report); return nil })
+			}
+			exit(k.code)
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,56 @@
+package grace
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// useExit replaces exit for the test, which sends the code to the returned channel instead.
+func useExit(t *testing.T) <-chan int {
+	codes := make(chan int, 1)
+	prev := exit
+	exit = func(code int) { codes <- code }
+	t.Cleanup(func() { exit = prev })
+	return codes
+}
+
+func TestWithHardKill_MustExit_WhenGracePeriodElapsed(t *testing.T) { // not parallel, as exit is replaced
+	codes := useExit(t)
+	release := make(chan struct{})
+	reports := make(chan Report, 1)
+	tsk := Named("stop", With(nil)).Then(Named("close", WithNoErr(func() { <-release })))
+	r := NewRunner(WithHardKill(time.Millisecond*20, 3, func(report Report) {
+		reports <- report
+		panic("must exit anyway")
+	}))
+
+	h := r.Start(context.Background(), tsk)
+	select {
+	case code := <-codes:
+		assert.Equal(t, 3, code)
+	case <-time.After(time.Second):
+		t.Fatal("must exit")
+	}
+	report := <-reports
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, "close", report.Steps[1].Name)
+	assert.ErrorIs(t, report.Steps[1].Err, ErrGracePeriod)
+	close(release)
+	assert.NoError(t, h.Wait())
+}
+
+func TestWithHardKill_MustNotExit_WhenRunReturnedInTime(t *testing.T) { // not parallel, as exit is replaced
+	codes := useExit(t)
+	r := NewRunner(WithHardKill(time.Millisecond*20, 1, nil), WithHardKill(0, 2, nil))
+	_, err := r.RunReport(context.Background(), With(nil))
+	assert.NoError(t, err)
+	assert.NoError(t, r.Start(context.Background(), With(nil)).Wait())
+
+	select {
+	case code := <-codes:
+		t.Fatalf("must not exit, but exited with %d", code)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
@@ -0,0 +1,69 @@
+package grace
+
+import (
+	"context"
+	"time"
+)
+
+// Heartbeat tells sign of life of the step in progress, with the context given to the step by a run.
+// Call it periodically from long-running steps, so that Watchdog keeps the step alive, and the report of the run
+// tells when the step has beaten last. Outside of a run, it does nothing.
+func Heartbeat(ctx context.Context) {
+	if beat, ok := ctx.Value(heartbeatKey{}).(func()); ok {
+		beat()
+	}
+}
+
+// heartbeatKey is the context key of the function that receives Heartbeat.
+type heartbeatKey struct{}
+
+// withHeartbeat returns a copy of the context, where Heartbeat calls given function, then those of the parent.
+func withHeartbeat(ctx context.Context, beat func()) context.Context {
+	return context.WithValue(ctx, heartbeatKey{}, func() {
+		beat()
+		Heartbeat(ctx)
+	})
+}
+
+// WithHeartbeat returns new Task that runs given step, calling beat on every each interval while the step runs,
+// such as to tell liveness of a long-running step to monitoring. Nil beat calls Heartbeat with the context of
+// the step instead, which reaches Watchdog and the report of the run. Beats stop as the step returns, and never
+// come after the Task returns. A panic from beat is recovered. Non-positive interval never beats.
+func WithHeartbeat(step StepCtx, interval time.Duration, beat func()) Task {
+	if step == nil {
+		return With(nil)
+	}
+	if interval <= 0 {
+		return withCtx(step)
+	}
+	return withCtx(func(ctx context.Context) error {
+		beat := beat
+		if beat == nil {
+			beat = func() { Heartbeat(ctx) }
+		}
+		done, stopped := make(chan struct{}), make(chan struct{})
+		ticker, stop := now().NewTicker(interval)
+		go func() {
+			defer close(stopped)
+			defer stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker:
+				}
+				select {
+				case <-done: // finished at the same time
+					return
+				default:
+				}
+				_ = guard(func() error { beat(); return nil })
+			}
+		}()
+		defer func() {
+			close(done)
+			<-stopped
+		}()
+		return step(ctx)
+	})
+}
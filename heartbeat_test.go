@@ -0,0 +1,101 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat_MustBeNoop_OutsideRun(t *testing.T) {
+	t.Parallel()
+	assert.NotPanics(t, func() { Heartbeat(context.Background()) })
+}
+
+func TestHeartbeat_MustBeReported(t *testing.T) {
+	t.Parallel()
+	var beaten time.Time
+	tsk := With(nil).Then(WithCtx(func(ctx context.Context) error {
+		Heartbeat(ctx)
+		beaten = time.Now()
+		time.Sleep(time.Millisecond * 10)
+		return nil
+	}))
+
+	report, err := tsk.RunReport(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, report.Steps[0].Heartbeat)
+	assert.WithinDuration(t, beaten, report.Steps[1].Heartbeat, time.Millisecond*5)
+	assert.True(t, report.Steps[1].Start.Before(report.Steps[1].Heartbeat))
+}
+
+func TestHeartbeat_MustReachBothWatchdogAndReport(t *testing.T) {
+	t.Parallel()
+	tsk := Watchdog(WithCtx(func(ctx context.Context) error {
+		for i := 0; i < 5; i++ {
+			time.Sleep(time.Millisecond * 20)
+			Heartbeat(ctx)
+		}
+		return nil
+	}), time.Millisecond*50)
+
+	report, err := tsk.RunReport(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.Steps[0].Heartbeat.IsZero())
+}
+
+func TestWithHeartbeat_MustBeat_WhileStepRuns(t *testing.T) { // not parallel, as the clock is replaced
+	c := useFakeClock(t)
+	beats := &atomic.Int32{}
+	release := make(chan struct{})
+	tsk := WithHeartbeat(func(context.Context) error {
+		<-release
+		return nil
+	}, time.Second*10, func() { beats.Add(1) })
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- tsk.Run(context.Background()) }()
+	for i := 1; i <= 3; i++ { // runs for 30s
+		c.AwaitWaiters(t, 1)
+		c.Advance(time.Second * 10)
+		assert.Eventually(t, func() bool { return beats.Load() == int32(i) }, time.Second, time.Millisecond)
+	}
+	close(release)
+	assert.NoError(t, <-errChan)
+
+	c.Advance(time.Minute)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(3), beats.Load(), "must not beat after completion")
+}
+
+func TestWithHeartbeat_MustReachHeartbeat_WhenBeatIsNil(t *testing.T) {
+	c := useFakeClock(t)
+	release := make(chan struct{})
+	tsk := Named("compact", WithHeartbeat(func(context.Context) error {
+		<-release
+		return nil
+	}, time.Second, nil))
+
+	reportChan := make(chan Report, 1)
+	go func() {
+		report, _ := tsk.RunReport(context.Background())
+		reportChan <- report
+	}()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Second)
+	c.AwaitWaiters(t, 1) // beaten, and waiting for the next
+	close(release)
+
+	report := <-reportChan
+	assert.False(t, report.Steps[0].Heartbeat.IsZero())
+}
+
+func TestWithHeartbeat_MustReturnErrorOfStep(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	assert.ErrorIs(t, WithHeartbeat(func(context.Context) error { return failure }, time.Hour, nil).Run(context.Background()), failure)
+	assert.ErrorIs(t, WithHeartbeat(func(context.Context) error { return failure }, 0, nil).Run(context.Background()), failure)
+	assert.NoError(t, WithHeartbeat(nil, time.Second, nil).Run(context.Background()))
+}
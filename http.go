@@ -0,0 +1,56 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrHTTPStatus is the error of a response with unexpected status, as checked by HTTPStep by default.
+var ErrHTTPStatus = errors.New("unexpected http status")
+
+// httpDrainLimit is how much of the body HTTPStep drains, so that the connection can be reused.
+const httpDrainLimit = 64 << 10
+
+// HTTPStep returns new Task that sends a clone of given request with the context given to Run, then checks the
+// response by ok, such as for webhooks to tell others that the service is going away. Nil ok accepts 2xx statuses
+// only, failing others with ErrHTTPStatus. The body of the response is drained and closed after the check.
+// Nil client uses http.DefaultClient. As the request is sent on every each run, such as by retries, its body is
+// renewed by GetBody of the request, which http.NewRequest sets for common bodies.
+//
+//	notify := grace.HTTPStep(nil, req, nil)
+//	shutdown := grace.WithRetry(grace.Bind(ctx, notify), 3, time.Second)
+func HTTPStep(client *http.Client, req *http.Request, ok func(resp *http.Response) error) Task {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if ok == nil {
+		ok = func(resp *http.Response) error {
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				return fmt.Errorf("%w: %s", ErrHTTPStatus, resp.Status)
+			}
+			return nil
+		}
+	}
+	return withCtx(func(ctx context.Context) error {
+		r := req.Clone(ctx)
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			r.Body = body
+		}
+		resp, err := client.Do(r)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, httpDrainLimit))
+			_ = resp.Body.Close()
+		}()
+		return ok(resp)
+	})
+}
@@ -0,0 +1,81 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPStep_MustSendRequest_OnEveryEachRun(t *testing.T) {
+	t.Parallel()
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("going away"))
+	assert.NoError(t, err)
+
+	tsk := HTTPStep(server.Client(), req, nil)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []string{"going away", "going away"}, bodies)
+}
+
+func TestHTTPStep_MustFail_WhenStatusRejected(t *testing.T) {
+	t.Parallel()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	err := HTTPStep(nil, req, nil).Run(context.Background())
+	assert.ErrorIs(t, err, ErrHTTPStatus)
+	assert.EqualError(t, err, "unexpected http status: 503 Service Unavailable")
+
+	tsk := WithRetry(Bind(context.Background(), HTTPStep(nil, req, nil)), 3, 0)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, int32(3), calls.Load())
+
+	teapot := errors.New("not a teapot")
+	err = HTTPStep(nil, req, func(resp *http.Response) error {
+		if resp.StatusCode != http.StatusTeapot {
+			return teapot
+		}
+		return nil
+	}).Run(context.Background())
+	assert.ErrorIs(t, err, teapot)
+}
+
+func TestHTTPStep_MustRespectContextDeadline(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	start := time.Now()
+	assert.ErrorIs(t, RunSync(ctx, HTTPStep(nil, req, nil)), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
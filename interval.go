@@ -0,0 +1,68 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStepInterval is the error of a chain failed by WithStepInterval, as a step has not completed in time.
+var ErrStepInterval = errors.New("step interval exceeded")
+
+// intervalKey is the context key of the function that tells completion of a step to WithStepInterval.
+type intervalKey struct{}
+
+// WithStepInterval returns new Task that runs given chain, but fails with ErrStepInterval once any step of the chain
+// does not complete within the interval, measured from the completion of the previous step, or the start of the chain.
+// The step in progress is told so by its context canceled with the error as the cause.
+func WithStepInterval(t Task, interval time.Duration) Task {
+	if isNil(t) {
+		return With(nil)
+	}
+	chain := Intercept(t, func(_ StepInfo, step StepCtx) StepCtx {
+		return func(ctx context.Context) error {
+			err := step(ctx)
+			if completed, ok := ctx.Value(intervalKey{}).(func()); ok {
+				completed()
+			}
+			return err
+		}
+	})
+	return withCtx(func(ctx context.Context) error {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		completions, completed := make(chan struct{}, 1), &atomic.Int64{}
+		ctx = context.WithValue(ctx, intervalKey{}, func() {
+			completed.Add(1)
+			select {
+			case completions <- struct{}{}:
+			default: // a completion is pending already
+			}
+		})
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- chain.Run(ctx)
+		}()
+
+		alarm := newAlarm(interval)
+		defer alarm.stop()
+		for {
+			select {
+			case err := <-errChan:
+				return err
+			case <-completions:
+				alarm.reset()
+			case <-alarm.C:
+				if !alarm.fired() {
+					continue
+				}
+				err := fmt.Errorf("%w: step %d has not completed in %v", ErrStepInterval, completed.Load(), interval)
+				cancel(err)
+				return err
+			}
+		}
+	})
+}
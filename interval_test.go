@@ -0,0 +1,40 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithStepInterval_MustFail_WhenStepTooSlow(t *testing.T) {
+	t.Parallel()
+	canceled, last := make(chan error, 1), &atomic.Bool{}
+	fast := WithNoErr(func() { time.Sleep(time.Millisecond * 20) })
+	slow := WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		canceled <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	tsk := WithStepInterval(fast.Then(fast).Then(fast).Then(slow).Then(WithNoErr(func() { last.Store(true) })), time.Millisecond*50)
+
+	start := time.Now()
+	err := tsk.Run(context.Background())
+	assert.ErrorIs(t, err, ErrStepInterval)
+	assert.EqualError(t, err, "step interval exceeded: step 3 has not completed in 50ms")
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+	assert.ErrorIs(t, <-canceled, ErrStepInterval)
+	assert.False(t, last.Load())
+}
+
+func TestWithStepInterval_MustPass_WhenEveryStepInTime(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	step := WithNoErr(func() { time.Sleep(time.Millisecond * 20) })
+
+	assert.NoError(t, WithStepInterval(step.Then(step).Then(step).Then(step), time.Millisecond*100).Run(context.Background()))
+	assert.ErrorIs(t, WithStepInterval(step.Then(With(func() error { return failure })), time.Second).Run(context.Background()), failure)
+	assert.NoError(t, WithStepInterval(nil, time.Second).Run(context.Background()))
+}
@@ -0,0 +1,31 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrStepLimitExceeded is returned by RunWithLimit, when the chain tries to run more tasks than the limit.
+var ErrStepLimitExceeded = errors.New("step limit exceeded")
+
+// RunWithLimit runs given Task as same as Task.Run does, but fails with ErrStepLimitExceeded once the chain tries
+// to run more tasks than maxSteps, guarding against runaway chains built dynamically, such as by Next of others.
+// Tasks of chains run by steps with the context, such as All, count towards the limit as well.
+func RunWithLimit(ctx context.Context, t Task, maxSteps int) error {
+	return run(context.WithValue(ctx, stepLimitKey{}, &stepLimit{max: int64(maxSteps)}), t, nil, nil)
+}
+
+// stepLimitKey is the context key of stepLimit.
+type stepLimitKey struct{}
+
+// stepLimit bounds the number of tasks run. Nil stepLimit bounds nothing.
+type stepLimit struct {
+	max   int64
+	steps atomic.Int64
+}
+
+// take counts another task, then tells whether the limit has allowed it.
+func (l *stepLimit) take() bool {
+	return l == nil || l.steps.Add(1) <= l.max
+}
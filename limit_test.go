@@ -0,0 +1,48 @@
+package grace
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// endless is a Task implemented outside of this package, which chain never ends.
+type endless struct {
+	Task
+	steps *int
+}
+
+func (e endless) Next() Task {
+	return e
+}
+
+func (e endless) Step() Step {
+	return func() error {
+		*e.steps++
+		return nil
+	}
+}
+
+func TestRunWithLimit_MustFail_WhenExceeded(t *testing.T) {
+	t.Parallel()
+	steps := 0
+	err := RunWithLimit(context.Background(), endless{With(nil), &steps}, 100)
+	assert.ErrorIs(t, err, ErrStepLimitExceeded)
+	assert.EqualError(t, err, "step limit exceeded: step 100")
+	assert.Equal(t, 100, steps)
+}
+
+func TestRunWithLimit_MustRun_WhenWithinLimit(t *testing.T) {
+	t.Parallel()
+	steps := 0
+	tsk := WithNoErr(func() { steps++ }).Then(WithNoErr(func() { steps++ })).Then(WithNoErr(func() { steps++ }))
+	assert.NoError(t, RunWithLimit(context.Background(), tsk, 3))
+	assert.Equal(t, 3, steps)
+}
+
+func TestRunWithLimit_MustCountNestedChains(t *testing.T) {
+	t.Parallel()
+	tsk := All(With(nil), With(nil)).Then(With(nil))
+	assert.NoError(t, RunWithLimit(context.Background(), tsk, 4))
+	assert.ErrorIs(t, RunWithLimit(context.Background(), tsk, 3), ErrStepLimitExceeded)
+}
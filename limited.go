@@ -0,0 +1,36 @@
+package grace
+
+import "context"
+
+// Waiter blocks until an operation is allowed, or the context is done, such as *rate.Limiter of
+// golang.org/x/time/rate does.
+type Waiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Limited returns new Task that waits for given Waiter before running given Task, such as to let every each of tasks
+// run by RunBatch respect a rate limit of an API. An error from the Waiter is returned as is, without running the Task,
+// and the Waiter is never waited for once the context is done, so that no token is consumed in vain.
+// Nil Waiter returns given Task as is.
+//
+//	limiter := rate.NewLimiter(rate.Every(time.Second/10), 1)
+//	errs := grace.RunBatch(ctx, grace.Limited(limiter, a), grace.Limited(limiter, b))
+func Limited(lim Waiter, t Task) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	if lim == nil {
+		return t
+	}
+	node := withCtx(func(ctx context.Context) error {
+		if err := ctxErr(ctx); err != nil {
+			return err
+		}
+		if err := lim.Wait(ctx); err != nil {
+			return err
+		}
+		return t.Run(ctx)
+	})
+	node.await = true // Waiter returns promptly on cancellation
+	return node
+}
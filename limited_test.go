@@ -0,0 +1,73 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokens is a Waiter which hands out a token per tick, counting tokens taken.
+type tokens struct {
+	tick  chan struct{}
+	taken atomic.Int32
+}
+
+func (w *tokens) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.tick:
+		w.taken.Add(1)
+		return nil
+	}
+}
+
+func TestLimited_MustWait_BeforeRunning(t *testing.T) {
+	t.Parallel()
+	w := &tokens{tick: make(chan struct{})}
+	ran := &atomic.Bool{}
+	errChan := make(chan error, 1)
+	go func() { errChan <- Limited(w, WithNoErr(func() { ran.Store(true) })).Run(context.Background()) }()
+
+	time.Sleep(time.Millisecond * 20)
+	assert.False(t, ran.Load())
+	w.tick <- struct{}{}
+	assert.NoError(t, <-errChan)
+	assert.True(t, ran.Load())
+	assert.Equal(t, int32(1), w.taken.Load())
+}
+
+func TestLimited_MustReturnWaitError_AsIs(t *testing.T) {
+	t.Parallel()
+	ran := false
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	assert.ErrorIs(t, Limited(&tokens{}, WithNoErr(func() { ran = true })).Run(ctx), context.DeadlineExceeded)
+
+	failure := errors.New("burst exceeded")
+	err := RunSync(context.Background(), Limited(waiterFunc(func(context.Context) error { return failure }), WithNoErr(func() { ran = true })))
+	assert.Equal(t, failure, err)
+	assert.False(t, ran)
+}
+
+func TestLimited_MustNotWait_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	w := &tokens{tick: make(chan struct{}, 1)}
+	w.tick <- struct{}{} // a token available
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, exec(ctx, Limited(w, With(nil))), context.Canceled) // the step itself, as runs never start it
+	assert.Equal(t, int32(0), w.taken.Load())
+	assert.Len(t, w.tick, 1, "must not consume a token")
+}
+
+// waiterFunc is a function that implements Waiter.
+type waiterFunc func(ctx context.Context) error
+
+func (f waiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
@@ -0,0 +1,57 @@
+package grace
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger returns a copy of the context carrying given logger, which steps retrieve by LoggerFrom.
+// During a run, the logger given to every each step has attributes of the step attached, such as its name.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	if logger == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, loggerKey{}, &ctxLogger{base: logger, logger: logger})
+}
+
+// LoggerFrom returns the logger carried by the context, or a logger that discards everything if none.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*ctxLogger); ok {
+		return l.logger
+	}
+	return discardLogger
+}
+
+// loggerKey is the context key of ctxLogger.
+type loggerKey struct{}
+
+// ctxLogger is a logger carried by a context, along with the one given to WithLogger,
+// so that attributes of steps are never piled up by nested runs.
+type ctxLogger struct {
+	base   *slog.Logger
+	logger *slog.Logger
+}
+
+// withStepLogger returns a copy of the context, where the logger has attributes of the task of given index.
+// It returns the context as is when no logger is carried.
+func withStepLogger(ctx context.Context, index int, name string) context.Context {
+	l, ok := ctx.Value(loggerKey{}).(*ctxLogger)
+	if !ok {
+		return ctx
+	}
+	logger := l.base.With(slog.Int("step_index", index))
+	if name != "" {
+		logger = logger.With(slog.String("step", name))
+	}
+	return context.WithValue(ctx, loggerKey{}, &ctxLogger{base: l.base, logger: logger})
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler is slog.Handler that discards everything.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
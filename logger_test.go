@@ -0,0 +1,54 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFrom_MustReturnInjectedLogger_WithStepAttributes(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logStep := func(ctx context.Context) error {
+		LoggerFrom(ctx).Info("hello")
+		return nil
+	}
+	tsk := Named("drain", WithCtx(logStep)).
+		Then(WithCtx(logStep)).
+		Then(WithCtx(func(ctx context.Context) error { return WithCtx(logStep).Run(ctx) }))
+
+	assert.NoError(t, tsk.Run(WithLogger(context.Background(), logger)))
+	assert.Equal(t, []string{
+		"level=INFO msg=hello step_index=0 step=drain",
+		"level=INFO msg=hello step_index=1",
+		"level=INFO msg=hello step_index=0",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"), "nested runs must not pile up attributes")
+}
+
+func TestLoggerFrom_MustReturnNoOpLogger_WhenAbsent(t *testing.T) {
+	t.Parallel()
+	logger := LoggerFrom(context.Background())
+	if assert.NotNil(t, logger) {
+		assert.False(t, logger.Enabled(context.Background(), slog.LevelError))
+		logger.With("key", "value").WithGroup("group").Error("discarded")
+	}
+	assert.Same(t, logger, LoggerFrom(WithLogger(context.Background(), nil)))
+
+	var got *slog.Logger
+	assert.NoError(t, WithCtx(func(ctx context.Context) error {
+		got = LoggerFrom(ctx)
+		return nil
+	}).Run(context.Background()))
+	assert.Same(t, logger, got)
+}
@@ -0,0 +1,101 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+)
+
+// MainOption configures Main.
+type MainOption func(cfg *mainConfig)
+
+type mainConfig struct {
+	signals []os.Signal
+	codes   []exitCode
+	runner  *Runner
+}
+
+// exitCode is the exit code for errors of the target.
+type exitCode struct {
+	target error
+	code   int
+}
+
+// WithExitCode returns MainOption that maps errors matching the target by errors.Is into the exit code.
+// Mappings are matched in order of registration, and errors matching none of them result in 1.
+func WithExitCode(target error, code int) MainOption {
+	return func(cfg *mainConfig) {
+		cfg.codes = append(cfg.codes, exitCode{target: target, code: code})
+	}
+}
+
+// WithSignals returns MainOption that listens to given signals, rather than DefaultSignals.
+func WithSignals(signals ...os.Signal) MainOption {
+	return func(cfg *mainConfig) {
+		cfg.signals = signals
+	}
+}
+
+// WithMainRunner returns MainOption that runs both the main and shutdown tasks by given Runner.
+// WithHardKill of the Runner applies to the shutdown only, as the main Task is meant to run until a signal.
+func WithMainRunner(r *Runner) MainOption {
+	return func(cfg *mainConfig) {
+		if r != nil {
+			cfg.runner = r
+		}
+	}
+}
+
+// Main runs the main Task until it ends or a signal arrives, then runs the shutdown Task as RunOnSignal does,
+// so that another signal forces the shutdown to quit. Once the shutdown returns, the context of the main Task is
+// canceled and awaited. It returns the exit code derived from errors of both, which are logged by the standard logger:
+// 0 on success, or as mapped by WithExitCode.
+//
+//	func main() {
+//		os.Exit(grace.Main(serve, shutdown, grace.WithExitCode(grace.ErrForceQuit, 130)))
+//	}
+func Main(run, shutdown Task, opts ...MainOption) int {
+	cfg := &mainConfig{signals: DefaultSignals(), runner: &Runner{}}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, cfg.signals...)
+	defer signal.Stop(signals)
+	return cfg.main(run, shutdown, signals)
+}
+
+// main runs as Main does, with the signals from given channel.
+func (cfg *mainConfig) main(run, shutdown Task, signals <-chan os.Signal) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner := *cfg.runner
+	runner.hardKill = nil // the main Task runs long by design
+	h := runner.Start(ctx, run)
+	select {
+	case <-h.Done():
+	case <-signals:
+	}
+	_, shutdownErr := cfg.runner.shutdown(context.Background(), signals, shutdown)
+	cancel()
+	runErr := h.Wait()
+	if isContextErr(runErr) && ctx.Err() != nil {
+		runErr = nil // canceled as requested after shutdown
+	}
+
+	err := errors.Join(runErr, shutdownErr)
+	if err == nil {
+		return 0
+	}
+	log.Printf("grace: %v", err)
+	for _, c := range cfg.codes {
+		if errors.Is(err, c.target) {
+			return c.code
+		}
+	}
+	return 1
+}
@@ -0,0 +1,91 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMain_MustRunShutdown_WhenMainEnds(t *testing.T) {
+	t.Parallel()
+	shutdown := &atomic.Bool{}
+	code := Main(With(nil), WithNoErr(func() { shutdown.Store(true) }), WithSignals(os.Interrupt), nil)
+	assert.Equal(t, 0, code)
+	assert.True(t, shutdown.Load())
+}
+
+func TestMain_MustRunShutdown_OnSignal_ThenCancelMain(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+	stopped := make(chan struct{})
+	serve := WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	shutdown := WithNoErr(func() { close(stopped) })
+
+	cfg := &mainConfig{runner: &Runner{}}
+	assert.Equal(t, 0, cfg.main(serve, shutdown, signals))
+	<-stopped
+}
+
+func TestMain_MustNotHardKillMain_BeforeSignal(t *testing.T) { // not parallel, as exit is replaced
+	codes := useExit(t)
+	signals := make(chan os.Signal, 1)
+	serve := WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cfg := &mainConfig{runner: NewRunner(WithHardKill(time.Millisecond*20, 3, nil))}
+
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		signals <- os.Interrupt
+	}()
+	assert.Equal(t, 0, cfg.main(serve, With(nil), signals))
+	select {
+	case code := <-codes:
+		t.Fatalf("must not exit, but exited with %d", code)
+	default:
+	}
+}
+
+func TestMain_MustHardKillShutdown(t *testing.T) { // not parallel, as exit is replaced
+	codes := useExit(t)
+	release := make(chan struct{})
+	defer close(release)
+	cfg := &mainConfig{runner: NewRunner(WithHardKill(time.Millisecond*20, 3, nil))}
+
+	go cfg.main(With(nil), WithNoErr(func() { <-release }), nil)
+	select {
+	case code := <-codes:
+		assert.Equal(t, 3, code)
+	case <-time.After(time.Second):
+		t.Fatal("must exit")
+	}
+}
+
+func TestMain_MustMapErrors_IntoExitCodes(t *testing.T) { // not parallel, as the standard logger is global
+	buf := &bytes.Buffer{}
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(buf)
+	errDrain, errOther := errors.New("drain timeout"), errors.New("other")
+	cfg := &mainConfig{runner: &Runner{}}
+	WithExitCode(errDrain, 3)(cfg)
+	WithMainRunner(NewRunner())(cfg)
+
+	shutdown := With(func() error { return fmt.Errorf("closing: %w", errDrain) })
+	assert.Equal(t, 3, cfg.main(With(nil), shutdown, nil))
+	assert.Contains(t, buf.String(), "grace: closing: drain timeout")
+
+	assert.Equal(t, 1, cfg.main(With(func() error { return errOther }), With(nil), nil))
+	assert.Contains(t, buf.String(), "grace: other")
+}
@@ -0,0 +1,104 @@
+package grace
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoOption configures Memo.
+type MemoOption func(m *memo)
+
+// MemoErrors returns MemoOption that caches errors as well, except those of context cancellation or deadline.
+func MemoErrors() MemoOption {
+	return func(m *memo) {
+		m.errors = true
+	}
+}
+
+// Memo returns new Task that runs given Task, then caches its result for ttl, so that runs in the meantime
+// return the cached result without running given Task again. Only success is cached, unless MemoErrors is given.
+// Runs while the cache is missing collapse into a single run of given Task, where every each of them returns
+// the same result, unless the context of one is done first. Use Invalidate to discard the cached result.
+func Memo(t Task, ttl time.Duration, opts ...MemoOption) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	m := &memo{ttl: ttl}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	node := withCtx(func(ctx context.Context) error {
+		return m.run(ctx, t)
+	})
+	node.await, node.invalidate = true, m.invalidate
+	return node
+}
+
+// Invalidate discards the result cached by given Task returned from Memo, so that the next run runs it again.
+// It does nothing for other tasks.
+func Invalidate(t Task) {
+	if node, ok := t.(*task); ok && node != nil && node.invalidate != nil {
+		node.invalidate()
+	}
+}
+
+// memo caches the result of a Task.
+type memo struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	errors     bool
+	cached     bool
+	err        error
+	expires    time.Time
+	inflight   *memoCall
+	generation int // increases on every each invalidation, so that a run in flight never caches a stale result
+}
+
+// memoCall is a run of the Task in flight, which others wait for.
+type memoCall struct {
+	done chan struct{}
+	err  error
+}
+
+// run returns the cached result if present, or runs given Task to cache its result otherwise.
+func (m *memo) run(ctx context.Context, t Task) error {
+	m.mu.Lock()
+	if m.cached && now().Now().Before(m.expires) {
+		defer m.mu.Unlock()
+		return m.err
+	}
+	if call := m.inflight; call != nil {
+		m.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctxErr(ctx)
+		}
+	}
+	call, generation := &memoCall{done: make(chan struct{})}, m.generation
+	m.inflight = call
+	m.mu.Unlock()
+
+	defer close(call.done)
+	call.err = t.Run(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inflight = nil
+	if generation == m.generation && (call.err == nil || (m.errors && !isContextErr(call.err))) {
+		m.cached, m.err, m.expires = true, call.err, now().Now().Add(m.ttl)
+	}
+	return call.err
+}
+
+// invalidate discards the cached result.
+func (m *memo) invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cached, m.err = false, nil
+	m.generation++
+}
@@ -0,0 +1,79 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemo_MustCacheSuccess_UntilExpired(t *testing.T) {
+	t.Parallel()
+	count := &atomic.Int32{}
+	tsk := Memo(WithNoErr(func() { count.Add(1) }), time.Millisecond*50)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, tsk.Run(context.Background()))
+	}
+	assert.Equal(t, int32(1), count.Load())
+
+	time.Sleep(time.Millisecond * 60)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, int32(2), count.Load())
+}
+
+func TestMemo_MustRunAgain_WhenInvalidated(t *testing.T) {
+	t.Parallel()
+	count := &atomic.Int32{}
+	tsk := Memo(WithNoErr(func() { count.Add(1) }), time.Hour)
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	Invalidate(tsk)
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.NoError(t, With(nil).Then(tsk).Run(context.Background()))
+	assert.Equal(t, int32(2), count.Load())
+	assert.NotPanics(t, func() { Invalidate(With(nil)) })
+}
+
+func TestMemo_MustCacheErrors_OnlyWhenConfigured(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	count := &atomic.Int32{}
+	step := With(func() error {
+		count.Add(1)
+		return failure
+	})
+
+	tsk := Memo(step, time.Hour)
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, int32(2), count.Load())
+
+	tsk = Memo(step, time.Hour, MemoErrors())
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, int32(3), count.Load())
+}
+
+func TestMemo_MustCollapseConcurrentMisses(t *testing.T) {
+	t.Parallel()
+	count := &atomic.Int32{}
+	tsk := Memo(WithNoErr(func() {
+		count.Add(1)
+		time.Sleep(time.Millisecond * 50)
+	}), time.Hour)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, tsk.Run(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), count.Load())
+}
@@ -0,0 +1,24 @@
+package grace
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mermaidEscaper escapes characters that are not allowed in quoted Mermaid labels.
+var mermaidEscaper = strings.NewReplacer(`"`, `#quot;`)
+
+// ToMermaid returns a Mermaid flowchart of the chain without running it.
+// Tasks are labeled as same as ToDOT does.
+func ToMermaid(t Task) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	labels := labels(t)
+	for i, l := range labels {
+		fmt.Fprintf(&b, "    n%d[\"%s\"]\n", i, mermaidEscaper.Replace(l))
+	}
+	for i := 1; i < len(labels); i++ {
+		fmt.Fprintf(&b, "    n%d --> n%d\n", i-1, i)
+	}
+	return b.String()
+}
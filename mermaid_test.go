@@ -0,0 +1,38 @@
+package grace
+
+import (
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files under testdata")
+
+// golden compares actual with the content of the golden file, or overwrites the file when -update is given.
+func golden(t *testing.T, name, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		assert.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestToMermaid_MustMatchGolden(t *testing.T) {
+	t.Parallel()
+	tsk := Named("stop-http", With(nil)).
+		Then(Named("drain-queue", With(nil))).
+		Then(With(nil)).
+		Then(Named(`close "db"`, With(nil))).
+		Then(Named("drain-queue", With(nil)))
+	golden(t, "mermaid", ToMermaid(tsk))
+}
+
+func TestToMermaid_MustRenderHeaderOnly_WhenNil(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "flowchart TD\n", ToMermaid(nil))
+}
@@ -0,0 +1,57 @@
+package grace
+
+import (
+	"context"
+	"sync"
+)
+
+// Middleware wraps a step with cross-cutting concerns, such as logging, tracing or recovery.
+type Middleware func(next Step) Step
+
+// middlewares registered by Use
+var middlewares struct {
+	sync.RWMutex
+	stack []Middleware
+}
+
+// Use registers middlewares to wrap every each step created afterwards, by With as well as by WithCtx and helpers
+// such as WithRetry, WithTimeout, All and Shield. A step receiving the context is wrapped on every each run, with
+// the context bound to it. Steps created before the registration are not affected.
+// Middlewares are applied in order of registration, where the first one is the outermost.
+func Use(mw ...Middleware) {
+	middlewares.Lock()
+	defer middlewares.Unlock()
+	for _, m := range mw {
+		if m != nil {
+			middlewares.stack = append(middlewares.stack, m)
+		}
+	}
+}
+
+// applyMiddlewaresCtx wraps given step with middlewares registered at the moment, on every each run.
+func applyMiddlewaresCtx(step StepCtx) StepCtx {
+	stack := registered()
+	if len(stack) == 0 {
+		return step
+	}
+	return func(ctx context.Context) error {
+		return wrapStep(stack, func() error { return step(ctx) })()
+	}
+}
+
+// registered returns middlewares registered thus far.
+func registered() []Middleware {
+	middlewares.RLock()
+	defer middlewares.RUnlock()
+	return middlewares.stack[:len(middlewares.stack):len(middlewares.stack)]
+}
+
+// wrapStep wraps given step with the middlewares, where the first one is the outermost.
+func wrapStep(stack []Middleware, step Step) Step {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if wrapped := stack[i](step); wrapped != nil {
+			step = wrapped
+		}
+	}
+	return step
+}
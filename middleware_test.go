@@ -0,0 +1,114 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// useForTest registers middlewares for the duration of the test.
+// Tests using it must not be parallel, as middlewares are shared across the package.
+func useForTest(t *testing.T, mw ...Middleware) {
+	t.Cleanup(func() {
+		middlewares.Lock()
+		defer middlewares.Unlock()
+		middlewares.stack = nil
+	})
+	Use(mw...)
+}
+
+func TestUse_MustWrapStepsInOrder(t *testing.T) {
+	sink, store := Collect[string]()
+	trace := func(name string) Middleware {
+		return func(next Step) Step {
+			return func() error {
+				_ = store(valueOf("before " + name))()
+				defer func() { _ = store(valueOf("after " + name))() }()
+				return next()
+			}
+		}
+	}
+	useForTest(t, trace("outer"), nil)
+	Use(trace("inner"))
+
+	assert.NoError(t, WithNoErr(func() { _ = store(valueOf("step"))() }).Run(context.Background()))
+	assert.Equal(t, []string{"before outer", "before inner", "step", "after inner", "after outer"}, sink.Values())
+}
+
+func TestUse_MustNotAffectStepsCreatedBefore(t *testing.T) {
+	calls := 0
+	created := With(nil)
+	useForTest(t, func(next Step) Step {
+		return func() error {
+			calls++
+			return next()
+		}
+	})
+
+	assert.NoError(t, created.Run(context.Background()))
+	assert.Equal(t, 0, calls)
+	assert.NoError(t, With(nil).Run(context.Background()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestUse_MustKeepStep_WhenMiddlewareReturnsNil(t *testing.T) {
+	called := false
+	useForTest(t, func(next Step) Step { return nil })
+
+	assert.NoError(t, WithNoErr(func() { called = true }).Run(context.Background()))
+	assert.True(t, called)
+}
+
+func TestUse_MustRecoverPanic_FromMiddleware(t *testing.T) {
+	logged := false
+	useForTest(t, func(next Step) Step {
+		return func() error {
+			logged = true
+			panic(errors.New("logger closed"))
+		}
+	})
+	reached := false
+	tsk := Named("save", With(nil)).Then(WithNoErr(func() { reached = true }))
+
+	err := tsk.Run(context.Background())
+	assert.True(t, logged)
+	assert.False(t, reached)
+	assert.EqualError(t, err, "task 'save' (step 0): logger closed")
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestUse_MustWrapStepsWithCtx_OnEveryRun(t *testing.T) {
+	calls := 0
+	useForTest(t, func(next Step) Step {
+		return func() error {
+			calls++
+			return next()
+		}
+	})
+	var value any
+	tsk := WithCtx(func(ctx context.Context) error {
+		value = ctx.Value(ctxKey{})
+		return nil
+	}).Then(WithRetry(func() error { return nil }, 3, 0))
+
+	assert.NoError(t, tsk.Run(context.WithValue(context.Background(), ctxKey{}, "value")))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "value", value, "must bind the context")
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 4, calls)
+}
+
+func TestUse_MustKeepWrappedNoop_OnCompact(t *testing.T) {
+	calls := 0
+	useForTest(t, func(next Step) Step {
+		return func() error {
+			calls++
+			return next()
+		}
+	})
+
+	assert.NoError(t, With(nil).Then(With(nil)).Compact().Run(context.Background()))
+	assert.Equal(t, 2, calls)
+}
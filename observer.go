@@ -0,0 +1,73 @@
+package grace
+
+import (
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// Observer observes lifecycle events of runs by Runner, such as for logging, metrics, and tracing.
+// Methods are called synchronously from the goroutine of the run, thus should return quickly.
+type Observer interface {
+	// OnStepStart is called when a task has started.
+	OnStepStart(info StepInfo)
+	// OnStepEnd is called when a task has ended with the error, including PanicError.
+	OnStepEnd(info StepInfo, err error)
+	// OnPanic is called when a task has panicked with the value and the stack trace, right before OnStepEnd.
+	OnPanic(info StepInfo, value any, stack []byte)
+	// OnRunEnd is called when a run has returned with the Report.
+	OnRunEnd(report Report)
+}
+
+// WithObserver returns RunOption that notifies given observers in order of registration.
+// A panic from an observer is recovered and logged by the standard logger, without affecting the run or the others.
+func WithObserver(obs ...Observer) RunOption {
+	return func(r *Runner) {
+		for _, o := range obs {
+			if o != nil {
+				r.observers = append(r.observers, o)
+			}
+		}
+	}
+}
+
+// notify calls fn with every each observer in order, recovering from panics of them.
+func notify(observers []Observer, fn func(o Observer)) {
+	for _, o := range observers {
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					log.Printf("grace: observer %T panicked: %v\n%s", o, p, debug.Stack())
+				}
+			}()
+			fn(o)
+		}()
+	}
+}
+
+// StepObserver is notified of every each task started and ended by Task.RunObserved, a lighter alternative to Observer.
+type StepObserver interface {
+	// OnStart is called when a task has started.
+	OnStart(index int, name string)
+	// OnEnd is called when a task has ended with the error, including PanicError, and the duration it took.
+	OnEnd(index int, name string, err error, d time.Duration)
+}
+
+// stepObserver adapts StepObserver into Observer. Tasks of a run start and end one by one, hence no lock.
+type stepObserver struct {
+	observer StepObserver
+	start    time.Time
+}
+
+func (s *stepObserver) OnStepStart(info StepInfo) {
+	s.start = time.Now()
+	s.observer.OnStart(info.Index, info.Name)
+}
+
+func (s *stepObserver) OnStepEnd(info StepInfo, err error) {
+	s.observer.OnEnd(info.Index, info.Name, err, time.Since(s.start))
+}
+
+func (s *stepObserver) OnPanic(StepInfo, any, []byte) {}
+
+func (s *stepObserver) OnRunEnd(Report) {}
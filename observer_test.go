@@ -0,0 +1,132 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"testing"
+	"time"
+)
+
+// events is an Observer which records events in order.
+type events struct {
+	prefix string
+	out    *[]string
+}
+
+func (e events) OnStepStart(info StepInfo) {
+	*e.out = append(*e.out, fmt.Sprintf("%sstart %d %s", e.prefix, info.Index, info.Name))
+}
+
+func (e events) OnStepEnd(info StepInfo, err error) {
+	*e.out = append(*e.out, fmt.Sprintf("%send %d %v", e.prefix, info.Index, err))
+}
+
+func (e events) OnPanic(info StepInfo, value any, stack []byte) {
+	*e.out = append(*e.out, fmt.Sprintf("%spanic %d %v %t", e.prefix, info.Index, value, len(stack) > 0))
+}
+
+func (e events) OnRunEnd(report Report) {
+	*e.out = append(*e.out, fmt.Sprintf("%srun %d", e.prefix, len(report.Steps)))
+}
+
+func TestWithObserver_MustNotifyLifecycleEvents(t *testing.T) {
+	t.Parallel()
+	var out []string
+	tsk := Named("first", With(nil)).
+		Then(With(func() error { return errors.New("failure") }).Fallback(With(nil))).
+		Then(WithNoErr(func() { panic("panicked") })).
+		Then(With(nil))
+
+	r := NewRunner(WithObserver(events{"", &out}, nil))
+	assert.Error(t, r.Run(context.Background(), tsk))
+	assert.Equal(t, []string{
+		"start 0 first",
+		"end 0 <nil>",
+		"start 1 ",
+		"end 1 <nil>",
+		"start 2 ",
+		"panic 2 panicked true",
+		"end 2 panicked",
+		"run 4",
+	}, out)
+}
+
+func TestWithObserver_MustNotifyInRegistrationOrder(t *testing.T) {
+	t.Parallel()
+	var out []string
+	r := NewRunner(WithObserver(events{"a:", &out}), WithObserver(events{"b:", &out}))
+	assert.NoError(t, r.Start(context.Background(), With(nil)).Wait())
+	assert.Equal(t, []string{"a:start 0 ", "b:start 0 ", "a:end 0 <nil>", "b:end 0 <nil>", "a:run 1", "b:run 1"}, out)
+}
+
+// panicky is an Observer which panics on every each event.
+type panicky struct{}
+
+func (panicky) OnStepStart(StepInfo)          { panic("start") }
+func (panicky) OnStepEnd(StepInfo, error)     { panic("end") }
+func (panicky) OnPanic(StepInfo, any, []byte) { panic("panic") }
+func (panicky) OnRunEnd(Report)               { panic("run") }
+
+func TestWithObserver_MustRecoverPanic_FromObserver(t *testing.T) { // not parallel, as the standard logger is global
+	buf := &bytes.Buffer{}
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(buf)
+	var out []string
+	r := NewRunner(WithObserver(panicky{}, events{"", &out}))
+
+	report, err := r.RunReport(context.Background(), With(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, []string{"start 0 ", "end 0 <nil>", "run 1"}, out, "others must be notified")
+	assert.Contains(t, buf.String(), "grace: observer grace.panicky panicked: start")
+	assert.Contains(t, buf.String(), "grace: observer grace.panicky panicked: run")
+}
+
+// pairs is a StepObserver which records starts and ends.
+type pairs struct {
+	events []string
+	total  time.Duration
+}
+
+func (p *pairs) OnStart(index int, name string) {
+	p.events = append(p.events, fmt.Sprintf("start %d %s", index, name))
+}
+
+func (p *pairs) OnEnd(index int, name string, err error, d time.Duration) {
+	p.events = append(p.events, fmt.Sprintf("end %d %s %v", index, name, err))
+	p.total += d
+}
+
+func TestTask_RunObserved_MustPairStartAndEnd(t *testing.T) {
+	t.Parallel()
+	p := &pairs{}
+	tsk := Named("sleep", WithNoErr(func() { time.Sleep(time.Millisecond * 10) })).
+		Then(WithNoErr(func() { panic("panicked") })).
+		Then(With(nil))
+
+	err := tsk.RunObserved(context.Background(), p)
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, []string{"start 0 sleep", "end 0 sleep <nil>", "start 1 ", "end 1  panicked"}, p.events)
+	assert.GreaterOrEqual(t, p.total, time.Millisecond*10)
+	assert.NoError(t, With(nil).RunObserved(context.Background(), nil))
+}
+
+func TestTask_RunObserved_MustEnd_WhenAbandoned(t *testing.T) {
+	t.Parallel()
+	p := &pairs{}
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	tsk := Named("stuck", WithNoErr(func() {
+		cancel()
+		<-release
+	}))
+
+	assert.ErrorIs(t, tsk.RunObserved(ctx, p), context.Canceled)
+	assert.Equal(t, []string{"start 0 stuck", "end 0 stuck context canceled"}, p.events)
+}
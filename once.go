@@ -0,0 +1,33 @@
+package grace
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Once returns new Task that runs given Task at most once, however many times it is run.
+// Runs in the meantime wait for the first one to finish, then every each run returns the same error of it.
+// A run waiting for the first one returns the error of its own context when the context is done first.
+// Chains containing the Task share the single run, as Then copies the Task along with its state.
+func Once(t Task) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	started, done := &atomic.Bool{}, make(chan struct{})
+	var err error
+	node := withCtx(func(ctx context.Context) error {
+		if started.CompareAndSwap(false, true) {
+			defer close(done)
+			err = t.Run(ctx)
+			return err
+		}
+		select {
+		case <-done:
+			return err
+		case <-ctx.Done():
+			return ctxErr(ctx)
+		}
+	})
+	node.await = true
+	return node
+}
@@ -0,0 +1,59 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnce_MustRunOnce_ForConcurrentRuns(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	count := &atomic.Int32{}
+	tsk := Once(With(func() error {
+		count.Add(1)
+		time.Sleep(time.Millisecond * 50)
+		return failure
+	}))
+
+	wg := &sync.WaitGroup{}
+	errs := make([]error, 100)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = tsk.Run(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), count.Load())
+	for _, err := range errs {
+		assert.Equal(t, failure, err)
+	}
+	assert.Equal(t, failure, tsk.Run(context.Background()), "must return the cached result")
+	assert.Equal(t, failure, With(nil).Then(tsk).Run(context.Background()), "must share the run with chains")
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestOnce_MustStopWaiting_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	started, release := make(chan struct{}), make(chan struct{})
+	tsk := Once(WithNoErr(func() {
+		close(started)
+		<-release
+	}))
+	go func() { _ = tsk.Run(context.Background()) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, tsk.Run(context.Background()))
+}
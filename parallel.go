@@ -0,0 +1,140 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// All returns new Task that runs given tasks concurrently, and waits for every each of them to finish.
+// When any of them fails, the others are canceled and the first observed error is returned.
+// A panic of a task, even from Run of others, is isolated to the task as PanicError, which cancels the others
+// as same as a failure does; see RunStrict for exceptions.
+// When the context is done in the middle, *MultiError is returned along with the results of tasks already finished.
+func All(tasks ...Task) Task {
+	t := withCtx(func(parent context.Context) error {
+		ctx, cancel := context.WithCancelCause(parent)
+		defer cancel(nil)
+
+		results := make(chan BranchResult, len(tasks))
+		for i, t := range tasks {
+			if isNil(t) {
+				results <- BranchResult{Index: i}
+				continue
+			}
+			go func(i int, t Task) {
+				results <- BranchResult{Index: i, Err: branch(ctx, t, i)}
+			}(i, t)
+		}
+
+		var first error
+		completed := make([]BranchResult, 0, len(tasks))
+		for range tasks {
+			r := <-results
+			if isContextErr(r.Err) && ctx.Err() != nil {
+				continue // interrupted, rather than completed
+			}
+			if r.Err != nil && first == nil {
+				first = r.Err
+				cancel(fmt.Errorf("canceled by other task: %w", first))
+			}
+			completed = append(completed, r)
+		}
+
+		if first != nil {
+			return first
+		}
+		if err := ctxErr(parent); err != nil {
+			sort.Slice(completed, func(i, j int) bool { return completed[i].Index < completed[j].Index })
+			return &MultiError{Err: err, Completed: completed}
+		}
+		return nil
+	})
+	t.await = true // tasks are run with derived context, hence returns promptly on cancellation
+	return t
+}
+
+// RunBatch runs every each of given tasks independently, and returns their errors in order of the tasks.
+// Unlike All, failure of a task does not affect others. At most GOMAXPROCS tasks run concurrently,
+// and tasks not yet started when the context is done result in the context error.
+// A panic of a task is isolated to the task as PanicError, as same as All does.
+func RunBatch(ctx context.Context, tasks ...Task) []error {
+	errs, slots := make([]error, len(tasks)), make(chan struct{}, runtime.GOMAXPROCS(0))
+	wg := sync.WaitGroup{}
+	for i, t := range tasks {
+		if isNil(t) {
+			continue
+		}
+		if errs[i] = ctxErr(ctx); errs[i] != nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			errs[i] = ctxErr(ctx)
+			continue
+		case slots <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int, t Task) {
+			defer func() { <-slots; wg.Done() }()
+			errs[i] = branch(ctx, t, i)
+		}(i, t)
+	}
+	wg.Wait()
+	return errs
+}
+
+// branch runs given Task at the index of a parallel run, converting a panic escaping from its Run into PanicError,
+// so that it never takes down the process along with the others, unless strict.
+func branch(ctx context.Context, t Task, index int) (err error) {
+	if isStrict(ctx) {
+		return t.Run(ctx)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			err = &PanicError{Index: index, Name: nameOf(t), Value: p, Stack: debug.Stack()}
+		}
+	}()
+	return t.Run(ctx)
+}
+
+// BranchResult is a result of a task run by All.
+type BranchResult struct {
+	// Index of the task given to All.
+	Index int
+	// Err returned from the task, nil if succeeded.
+	Err error
+}
+
+// MultiError is returned when the context is done before every task run by All is finished.
+type MultiError struct {
+	// Err is the error of the context.
+	Err error
+	// Completed holds results of the tasks finished before the context is done, in order of their indices.
+	Completed []BranchResult
+}
+
+// Error implements error
+func (e *MultiError) Error() string {
+	return fmt.Sprintf("%v: %d task(s) completed", e.Err, len(e.Completed))
+}
+
+// Unwrap returns the context error, followed by errors of completed tasks if any.
+func (e *MultiError) Unwrap() []error {
+	errs := []error{e.Err}
+	for _, r := range e.Completed {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// isContextErr reports whether given error is caused by context cancellation or deadline.
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
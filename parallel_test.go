@@ -0,0 +1,229 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAll_MustRunConcurrently(t *testing.T) {
+	t.Parallel()
+	count := int32(0)
+	release := make(chan struct{})
+	branch := With(func() error {
+		if atomic.AddInt32(&count, 1) == 3 {
+			close(release)
+		}
+		select {
+		case <-release:
+			return nil
+		case <-time.After(time.Second * 5):
+			return errors.New("not concurrent")
+		}
+	})
+
+	assert.NoError(t, All(branch, branch, branch).Run(context.Background()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&count))
+}
+
+func TestAll_MustCancelOthers_WhenAnyFails(t *testing.T) {
+	t.Parallel()
+	started, canceled := make(chan struct{}), make(chan struct{})
+	blocking := withCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	})
+	failing := With(func() error {
+		<-started
+		return errors.New("failed")
+	})
+
+	err := All(blocking, failing).Run(context.Background())
+	assert.ErrorContains(t, err, "failed")
+	assert.NotErrorIs(t, err, context.Canceled)
+	<-canceled
+}
+
+func TestAll_MustReportCompleted_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	fast := WithNoErr(func() { close(finished) })
+	slow := withCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	go func() {
+		<-finished
+		cancel()
+	}()
+
+	err := All(slow, fast, slow).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var multi *MultiError
+	if assert.ErrorAs(t, err, &multi) {
+		assert.ErrorIs(t, multi.Err, context.Canceled)
+		assert.Equal(t, []BranchResult{{Index: 1}}, multi.Completed)
+	}
+}
+
+func TestAll_MustHandleEmptyAndNil_AsNoOp(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, All().Run(context.Background()))
+	assert.NoError(t, All(nil, With(nil)).Run(context.Background()))
+}
+
+func TestMultiError_MustUnwrapCompletedErrors(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	err := &MultiError{Err: context.Canceled, Completed: []BranchResult{{Index: 0}, {Index: 2, Err: failure}}}
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, "context canceled: 2 task(s) completed", err.Error())
+}
+
+func TestRunBatch_MustAlignResultsWithTasks(t *testing.T) {
+	t.Parallel()
+	e1, e3 := errors.New("first"), errors.New("third")
+	count := int32(0)
+	succeed := WithNoErr(func() { atomic.AddInt32(&count, 1) })
+	errs := RunBatch(context.Background(),
+		With(func() error { return e1 }),
+		succeed,
+		succeed.Then(With(func() error { return e3 })),
+		nil,
+		succeed,
+	)
+
+	assert.Len(t, errs, 5)
+	assert.ErrorIs(t, errs[0], e1)
+	assert.NoError(t, errs[1])
+	assert.ErrorIs(t, errs[2], e3)
+	assert.NoError(t, errs[3])
+	assert.NoError(t, errs[4])
+	assert.Equal(t, int32(3), atomic.LoadInt32(&count), "failures must not affect others")
+}
+
+func TestRunBatch_MustBoundConcurrency(t *testing.T) {
+	t.Parallel()
+	inFlight, peak := int32(0), int32(0)
+	tsk := WithNoErr(func() {
+		n := atomic.AddInt32(&inFlight, 1)
+		for p := atomic.LoadInt32(&peak); n > p && !atomic.CompareAndSwapInt32(&peak, p, n); p = atomic.LoadInt32(&peak) {
+		}
+		time.Sleep(time.Millisecond * 5)
+		atomic.AddInt32(&inFlight, -1)
+	})
+	tasks := make([]Task, runtime.GOMAXPROCS(0)*3)
+	for i := range tasks {
+		tasks[i] = tsk
+	}
+
+	for _, err := range RunBatch(context.Background(), tasks...) {
+		assert.NoError(t, err)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(runtime.GOMAXPROCS(0)))
+}
+
+func TestRunBatch_MustNotStart_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	started := int32(0)
+	errs := RunBatch(ctx, WithNoErr(func() { atomic.AddInt32(&started, 1) }))
+	assert.ErrorIs(t, errs[0], context.Canceled)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&started))
+	assert.Empty(t, RunBatch(ctx))
+}
+
+func TestAll_MustCancelOthers_WithCause(t *testing.T) {
+	t.Parallel()
+	started, causes, failure := make(chan struct{}), make(chan error, 1), errors.New("failure")
+	blocking := WithCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	failing := With(func() error {
+		<-started
+		return failure
+	})
+
+	assert.ErrorIs(t, RunSync(context.Background(), All(blocking, failing)), failure)
+	assert.ErrorIs(t, <-causes, failure)
+}
+
+// panicRun is a Task implemented outside of this package, which Run panics once wait is closed, if any.
+type panicRun struct {
+	Task
+	wait chan struct{}
+}
+
+func (p panicRun) Run(context.Context) error {
+	if p.wait != nil {
+		<-p.wait
+	}
+	panic("run panicked")
+}
+
+func TestAll_MustIsolatePanic_AndCancelOthers(t *testing.T) {
+	t.Parallel()
+	started, canceled := make(chan struct{}, 2), &atomic.Int32{}
+	blocking := withCtx(func(ctx context.Context) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		canceled.Add(1)
+		return ctx.Err()
+	})
+	panicking := WithNoErr(func() {
+		<-started
+		<-started
+		panic("step panicked")
+	})
+
+	err := All(blocking, panicking, blocking).Run(context.Background())
+	var pe *PanicError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "step panicked", pe.Value)
+	assert.Eventually(t, func() bool { return canceled.Load() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestAll_MustIsolatePanic_FromRunOfOthers(t *testing.T) {
+	t.Parallel()
+	started, canceled := make(chan struct{}), make(chan struct{})
+	blocking := withCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	})
+
+	err := All(blocking, With(nil), panicRun{With(nil), started}).Run(context.Background())
+	var pe *PanicError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, 2, pe.Index)
+	assert.Equal(t, "run panicked", pe.Value)
+	assert.NotEmpty(t, pe.Stack)
+	<-canceled
+}
+
+func TestRunBatch_MustIsolatePanic(t *testing.T) {
+	t.Parallel()
+	errs := RunBatch(context.Background(), With(nil), panicRun{Task: With(nil)}, WithNoErr(func() { panic("step panicked") }))
+
+	var pe *PanicError
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.As(errs[1], &pe))
+	assert.Equal(t, 1, pe.Index)
+	assert.True(t, errors.As(errs[2], &pe))
+	assert.Equal(t, "step panicked", pe.Value)
+}
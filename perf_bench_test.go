@@ -0,0 +1,67 @@
+package grace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// chainOf returns a chain of n no-op tasks.
+func chainOf(n int) Task {
+	t := With(nil)
+	for i := 1; i < n; i++ {
+		t = With(nil).Then(t)
+	}
+	return t
+}
+
+func BenchmarkTask_Run(b *testing.B) {
+	for _, n := range []int{1, 10} {
+		t, ctx := chainOf(n), context.Background()
+		b.Run(fmt.Sprintf("%d-steps", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = t.Run(ctx)
+			}
+		})
+	}
+}
+
+func BenchmarkRunSync(b *testing.B) {
+	for _, n := range []int{1, 10} {
+		t, ctx := chainOf(n), context.Background()
+		b.Run(fmt.Sprintf("%d-steps", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = RunSync(ctx, t)
+			}
+		})
+	}
+}
+
+func BenchmarkTask_Then(b *testing.B) {
+	for _, n := range []int{100, 1_000, 10_000} {
+		b.Run(fmt.Sprintf("%d-steps", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				t := With(nil)
+				for j := 1; j < n; j++ {
+					t = t.Then(With(nil))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	for _, n := range []int{100, 1_000, 10_000} {
+		b.Run(fmt.Sprintf("%d-steps", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				builder := &Builder{}
+				for j := 0; j < n; j++ {
+					builder.Add(nil)
+				}
+				_ = builder.Task()
+			}
+		})
+	}
+}
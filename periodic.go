@@ -0,0 +1,154 @@
+package grace
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PeriodicOption configures PeriodicRunner.
+type PeriodicOption func(p *PeriodicRunner)
+
+// WithErrorCallback returns PeriodicOption that gives errors of runs to fn, which keep the runner going by default.
+// A panic from fn is recovered.
+func WithErrorCallback(fn func(err error)) PeriodicOption {
+	return func(p *PeriodicRunner) {
+		p.onError = fn
+	}
+}
+
+// WithStopOnError returns PeriodicOption that stops the runner on the first error of a run, after the callback of
+// WithErrorCallback if any. The error is returned from Err then.
+func WithStopOnError() PeriodicOption {
+	return func(p *PeriodicRunner) {
+		p.stopOnError = true
+	}
+}
+
+// PeriodicRunner runs a chain on a schedule in background, until stopped, such as by RunEvery or Cron.
+// Runs never overlap; a tick passed while a run is in progress is dropped, thus the schedule does not drift.
+type PeriodicRunner struct {
+	task        Task
+	schedule    func(last time.Time) time.Time
+	onError     func(err error)
+	stopOnError bool
+
+	cancel   context.CancelFunc
+	stopping chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+	skipped  atomic.Int64
+}
+
+// RunEvery starts running given Task on every each interval in background, under a context of its own which only
+// Stop cancels. The first run starts after the first interval. Errors of runs are ignored unless configured by
+// options, such as WithErrorCallback. It panics when interval is not positive, as same as Every does.
+//
+//	janitor := grace.RunEvery(time.Minute, compact, grace.WithErrorCallback(func(err error) { log.Print(err) }))
+//	defer janitor.Stop(ctx)
+func RunEvery(interval time.Duration, t Task, opts ...PeriodicOption) *PeriodicRunner {
+	if interval <= 0 {
+		panic("grace: non-positive interval for RunEvery")
+	}
+	return startPeriodic(t, func(last time.Time) time.Time { return last.Add(interval) }, opts)
+}
+
+// startPeriodic starts running given Task at times given by schedule, which returns the time to run next after last.
+func startPeriodic(t Task, schedule func(last time.Time) time.Time, opts []PeriodicOption) *PeriodicRunner {
+	if isNil(t) {
+		t = With(nil)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &PeriodicRunner{
+		task:     t,
+		schedule: schedule,
+		cancel:   cancel,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	go p.loop(ctx)
+	return p
+}
+
+// loop runs the Task on the schedule, until stopped.
+func (p *PeriodicRunner) loop(ctx context.Context) {
+	defer close(p.done)
+	defer p.cancel()
+	next := p.schedule(now().Now())
+	for {
+		timer, stop := now().NewTimer(next.Sub(now().Now()))
+		select {
+		case <-p.stopping:
+			stop()
+			return
+		case <-timer:
+		}
+		select {
+		case <-p.stopping: // stopping wins over the tick
+			return
+		default:
+		}
+		if err := p.task.Run(ctx); err != nil {
+			if ctx.Err() != nil { // canceled by Stop
+				return
+			}
+			if p.onError != nil {
+				_ = guard(func() error { p.onError(err); return nil })
+			}
+			if p.stopOnError {
+				p.err = err
+				return
+			}
+		}
+		for current := now().Now(); !next.After(current); {
+			if next = p.schedule(next); next.IsZero() { // never again
+				return
+			}
+			if !next.After(current) {
+				p.skipped.Add(1)
+			}
+		}
+	}
+}
+
+// Stop prevents new runs, then waits for the run in progress to finish, if any. When the context is done first,
+// the run is canceled and the error of the context is returned, without waiting for the run any further.
+// It can be called multiple times.
+func (p *PeriodicRunner) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopping) })
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctxErr(ctx)
+	}
+}
+
+// Skipped returns the number of ticks dropped thus far, as they have passed while a run is in progress.
+func (p *PeriodicRunner) Skipped() int64 {
+	return p.skipped.Load()
+}
+
+// Done returns a channel closed when the runner has stopped, either by Stop or WithStopOnError.
+func (p *PeriodicRunner) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns the error of the run which has stopped the runner by WithStopOnError, once Done is closed.
+// Nil otherwise.
+func (p *PeriodicRunner) Err() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunEvery_MustRunOnEveryInterval_UntilStopped(t *testing.T) { // not parallel, as the clock is replaced
+	c := useFakeClock(t)
+	runs := &atomic.Int32{}
+	p := RunEvery(time.Minute, WithNoErr(func() { runs.Add(1) }))
+
+	for i := 1; i <= 3; i++ {
+		c.AwaitWaiters(t, 1)
+		c.Advance(time.Minute)
+		assert.Eventually(t, func() bool { return runs.Load() == int32(i) }, time.Second, time.Millisecond)
+	}
+	assert.NoError(t, p.Stop(context.Background()))
+	assert.NoError(t, p.Stop(context.Background()), "must be idempotent")
+	<-p.Done()
+	assert.Equal(t, int32(3), runs.Load())
+}
+
+func TestRunEvery_MustWaitForRunInProgress_OnStop(t *testing.T) {
+	c := useFakeClock(t)
+	started, release, finished := make(chan struct{}), make(chan struct{}), &atomic.Bool{}
+	p := RunEvery(time.Minute, WithNoErr(func() {
+		close(started)
+		<-release
+		finished.Store(true)
+	}))
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-started
+
+	go func() {
+		time.Sleep(time.Millisecond * 20)
+		close(release)
+	}()
+	assert.NoError(t, p.Stop(context.Background()))
+	assert.True(t, finished.Load())
+}
+
+func TestRunEvery_MustCancelRun_WhenStopIsBounded(t *testing.T) {
+	c := useFakeClock(t)
+	started, canceled := make(chan struct{}), make(chan struct{})
+	p := RunEvery(time.Minute, WithCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	}))
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	assert.ErrorIs(t, p.Stop(ctx), context.DeadlineExceeded)
+	<-canceled
+	<-p.Done()
+	assert.NoError(t, p.Err())
+}
+
+func TestRunEvery_MustKeepRunning_OnError(t *testing.T) {
+	c := useFakeClock(t)
+	failure := errors.New("failure")
+	errs := make(chan error, 2)
+	p := RunEvery(time.Minute, With(func() error { return failure }), WithErrorCallback(func(err error) { errs <- err }))
+
+	for i := 0; i < 2; i++ {
+		c.AwaitWaiters(t, 1)
+		c.Advance(time.Minute)
+		assert.ErrorIs(t, <-errs, failure)
+	}
+	assert.NoError(t, p.Stop(context.Background()))
+	assert.NoError(t, p.Err())
+}
+
+func TestRunEvery_MustStop_OnError_WithStopOnError(t *testing.T) {
+	c := useFakeClock(t)
+	failure := errors.New("failure")
+	called := &atomic.Bool{}
+	p := RunEvery(time.Minute, With(func() error { return failure }),
+		WithErrorCallback(func(error) { called.Store(true) }), WithStopOnError())
+	assert.NoError(t, p.Err(), "must be nil while running")
+
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Minute)
+	<-p.Done()
+	assert.ErrorIs(t, p.Err(), failure)
+	assert.True(t, called.Load())
+	assert.NoError(t, p.Stop(context.Background()))
+}
+
+func TestRunEvery_MustPanic_WhenIntervalIsNotPositive(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { RunEvery(0, With(nil)) })
+}
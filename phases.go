@@ -0,0 +1,105 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPhaseTimeout is the cause of the context of a phase timed out, which is set by Phases.Timeout.
+var ErrPhaseTimeout = errors.New("phase timeout")
+
+// Phases builds a Task of shutdown phases, such as stopping intake, draining work in flight, persisting state, and
+// releasing resources. Phases run sequentially in order of their first appearance, while tasks within a phase run
+// concurrently. A phase starts only after every each task of the previous phase has finished, or its timeout elapsed.
+// The zero value is ready to use.
+type Phases struct {
+	phases []*phase
+}
+
+// phase is a named group of tasks run concurrently.
+type phase struct {
+	name    string
+	tasks   []Task
+	timeout time.Duration
+}
+
+// Add adds given Task to the phase of the name, which is appended after others when it is new.
+func (p *Phases) Add(name string, t Task) *Phases {
+	ph := p.phase(name)
+	if !isNil(t) {
+		ph.tasks = append(ph.tasks, t)
+	}
+	return p
+}
+
+// Timeout sets the timeout of the phase of the name, which is appended after others when it is new.
+// When the timeout elapses, the context of tasks in the phase is canceled with ErrPhaseTimeout as the cause,
+// then the next phase starts. Non-positive timeout means no timeout, which is the default.
+func (p *Phases) Timeout(name string, timeout time.Duration) *Phases {
+	p.phase(name).timeout = timeout
+	return p
+}
+
+// phase returns the phase of the name, appending new one if none.
+func (p *Phases) phase(name string) *phase {
+	for _, ph := range p.phases {
+		if ph.name == name {
+			return ph
+		}
+	}
+	ph := &phase{name: name}
+	p.phases = append(p.phases, ph)
+	return ph
+}
+
+// Task returns a Task running phases added thus far. Failure of a phase does not prevent the next phase from running,
+// so that resources are released anyway; errors of every each phase are joined and returned.
+// When the context is done, the rest of phases never start.
+func (p *Phases) Task() Task {
+	// copy the current state, so that further additions do not affect the Task
+	phases := make([]phase, len(p.phases))
+	for i, ph := range p.phases {
+		phases[i] = phase{name: ph.name, tasks: append([]Task(nil), ph.tasks...), timeout: ph.timeout}
+	}
+
+	t := withCtx(func(ctx context.Context) error {
+		var errs []error
+		for _, ph := range phases {
+			if err := ctxErr(ctx); err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			if err := ph.run(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("phase %q: %w", ph.name, err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+	t.await = true // every task is run with given context, hence returns promptly on cancellation
+	return t
+}
+
+// run runs every each task of the phase concurrently, then returns their errors joined.
+func (ph phase) run(ctx context.Context) error {
+	if ph.timeout > 0 {
+		var cancel context.CancelFunc
+		cause := fmt.Errorf("%w after %v", ErrPhaseTimeout, ph.timeout)
+		ctx, cancel = context.WithTimeoutCause(ctx, ph.timeout, cause)
+		defer cancel()
+	}
+	errs, wg := make([]error, len(ph.tasks)), sync.WaitGroup{}
+	for i, t := range ph.tasks {
+		wg.Add(1)
+		go func(i int, t Task) {
+			defer wg.Done()
+			errs[i] = t.Run(ctx)
+		}(i, t)
+	}
+	wg.Wait()
+	if cause := context.Cause(ctx); errors.Is(cause, ErrPhaseTimeout) {
+		errs = append(errs, cause) // tasks may not tell the cause by themselves
+	}
+	return errors.Join(errs...)
+}
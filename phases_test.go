@@ -0,0 +1,102 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPhases_MustRunPhasesInOrder_AndTasksConcurrently(t *testing.T) {
+	t.Parallel()
+	mu := sync.Mutex{}
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+	both := &sync.WaitGroup{}
+	both.Add(2)
+	drain := func(name string) Task {
+		return WithNoErr(func() {
+			both.Done()
+			both.Wait() // never returns unless both run concurrently
+			record(name)
+		})
+	}
+	p := &Phases{}
+	p.Add("intake", WithNoErr(func() { record("intake") })).
+		Add("drain", drain("http")).
+		Add("release", WithNoErr(func() { record("release") })).
+		Add("drain", drain("queue"))
+
+	assert.NoError(t, p.Task().Run(context.Background()))
+	assert.Equal(t, "intake", order[0])
+	assert.ElementsMatch(t, []string{"http", "queue"}, order[1:3])
+	assert.Equal(t, "release", order[3])
+}
+
+func TestPhases_MustRunNextPhase_WhenTimedOut(t *testing.T) {
+	t.Parallel()
+	released := &atomic.Bool{}
+	p := (&Phases{}).
+		Timeout("drain", time.Millisecond*20).
+		Add("drain", WithCtx(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})).
+		Add("release", WithNoErr(func() { released.Store(true) }))
+
+	start := time.Now()
+	err := p.Task().Run(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, err, ErrPhaseTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, `phase "drain": `)
+	assert.True(t, released.Load())
+}
+
+func TestPhases_MustRunEveryEachPhase_AndJoinErrors(t *testing.T) {
+	t.Parallel()
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	ran := 0
+	p := (&Phases{}).
+		Add("drain", With(func() error { return e1 })).
+		Add("drain", WithNoErr(func() {})).
+		Add("persist", WithNoErr(func() { panic(e2) })).
+		Add("release", WithNoErr(func() { ran++ }))
+
+	err := p.Task().Run(context.Background())
+	assert.ErrorIs(t, err, e1)
+	assert.ErrorIs(t, err, e2)
+	assert.ErrorContains(t, err, `phase "persist": e2`)
+	assert.Equal(t, 1, ran)
+}
+
+func TestPhases_MustNotStartNextPhase_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := &atomic.Bool{}
+	p := (&Phases{}).
+		Add("intake", WithNoErr(cancel)).
+		Add("release", WithNoErr(func() { ran.Store(true) }))
+
+	assert.ErrorIs(t, RunSync(ctx, p.Task()), context.Canceled)
+	assert.False(t, ran.Load())
+}
+
+func TestPhases_Task_MustNotBeAffected_ByFurtherAdditions(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	p := (&Phases{}).Add("release", WithNoErr(func() { calls++ }))
+	tsk := p.Task()
+	p.Add("release", WithNoErr(func() { calls++ })).Add("more", nil)
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, (&Phases{}).Task().Run(context.Background()))
+}
@@ -0,0 +1,53 @@
+package grace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// progress is the serialized form of a Report, which lists the tasks completed in order of the chain.
+// Unnamed tasks are listed by their index, such as "#1".
+type progress struct {
+	Completed []string `json:"completed"`
+}
+
+// WriteTo writes the progress of the run to w, which is the tasks completed from the start of the chain,
+// so that the chain can be resumed by Restore, even after the process restarts. It implements io.WriterTo.
+func (r Report) WriteTo(w io.Writer) (int64, error) {
+	p := progress{Completed: []string{}}
+	for _, s := range r.Steps {
+		if !s.completed() {
+			break
+		}
+		p.Completed = append(p.Completed, s.label())
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(b, '\n'))
+	return int64(n), err
+}
+
+// Restore reads the progress written by Report.WriteTo from r, then returns the rest of given chain to be run.
+// It fails when the tasks completed do not match those of given chain, rather than skipping wrong tasks.
+func Restore(r io.Reader, t Task) (Task, error) {
+	var p progress
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to read progress: %w", err)
+	}
+	for index, name := range p.Completed {
+		if isNil(t) {
+			return nil, fmt.Errorf("progress has %d tasks completed, but chain has only %d", len(p.Completed), index)
+		}
+		if actual := label(t, index); actual != name {
+			return nil, fmt.Errorf("progress has task %q completed at %d, but chain has %q", name, index, actual)
+		}
+		t = t.Next()
+	}
+	if isNil(t) {
+		return With(nil), nil
+	}
+	return t, nil
+}
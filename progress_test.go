@@ -0,0 +1,69 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRestore_MustResumeFromWrittenProgress(t *testing.T) {
+	t.Parallel()
+	counts, broken := make([]int, 4), true
+	step := func(i int) Task {
+		return With(func() error {
+			if i == 2 && broken {
+				return errors.New("failure")
+			}
+			counts[i]++
+			return nil
+		})
+	}
+	tsk := Sequence(Named("create", step(0)), step(1), Named("migrate", step(2)), Named("index", step(3)))
+
+	report, err := tsk.RunReport(context.Background())
+	assert.Error(t, err)
+	buf := &bytes.Buffer{}
+	n, err := report.WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.JSONEq(t, `{"completed": ["create", "#1"]}`, buf.String())
+
+	broken = false
+	rest, err := Restore(buf, tsk)
+	assert.NoError(t, err)
+	assert.Equal(t, "migrate -> index (2 steps)", Describe(rest))
+	assert.NoError(t, rest.Run(context.Background()))
+	assert.Equal(t, []int{1, 1, 1, 1}, counts)
+}
+
+func TestRestore_MustFail_WhenChainMismatch(t *testing.T) {
+	t.Parallel()
+	tsk := Named("create", With(nil)).Then(Named("migrate", With(nil)))
+
+	_, err := Restore(strings.NewReader(`{"completed": ["create", "index"]}`), tsk)
+	assert.EqualError(t, err, `progress has task "index" completed at 1, but chain has "migrate"`)
+
+	_, err = Restore(strings.NewReader(`{"completed": ["create", "migrate", "index"]}`), tsk)
+	assert.EqualError(t, err, "progress has 3 tasks completed, but chain has only 2")
+
+	_, err = Restore(strings.NewReader(`not a json`), tsk)
+	assert.ErrorContains(t, err, "failed to read progress")
+}
+
+func TestRestore_MustReturnNoop_WhenCompleted(t *testing.T) {
+	t.Parallel()
+	tsk := Named("create", With(nil))
+	report, err := tsk.RunReport(context.Background())
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	_, err = report.WriteTo(buf)
+	assert.NoError(t, err)
+	rest, err := Restore(buf, tsk)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, Count(rest))
+	assert.Equal(t, "", nameOf(rest))
+}
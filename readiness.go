@@ -0,0 +1,78 @@
+package grace
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ReadinessGate tells whether the service is ready to receive traffic, such as through /readyz of load balancers.
+// The zero value is not ready, and it is safe for concurrent use.
+type ReadinessGate struct {
+	ready atomic.Bool
+}
+
+// Ready marks the service as ready.
+func (g *ReadinessGate) Ready() {
+	g.ready.Store(true)
+}
+
+// NotReady marks the service as not ready.
+func (g *ReadinessGate) NotReady() {
+	g.ready.Store(false)
+}
+
+// IsReady reports whether the service is ready.
+func (g *ReadinessGate) IsReady() bool {
+	return g.ready.Load()
+}
+
+// ServeHTTP implements http.Handler, which responds 200 when ready, or 503 otherwise.
+func (g *ReadinessGate) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !g.IsReady() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready\n"))
+		return
+	}
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// MarkNotReadyAndWait returns new Task that marks the service as not ready, then waits for given duration so that
+// load balancers notice it, which is the very first thing to do on shutdown. The wait ends early when the context
+// is done, failing with its error.
+func (g *ReadinessGate) MarkNotReadyAndWait(d time.Duration) Task {
+	t := withCtx(func(ctx context.Context) error {
+		g.NotReady()
+		return sleep(ctx, d)
+	})
+	t.await = true // returns promptly on cancellation
+	return t
+}
+
+// PreStopDelay returns new Task to be the first one of a shutdown chain, such as for preStop hooks of Kubernetes:
+// it marks given gates as not ready, then waits for given duration so that endpoints and load balancers stop
+// routing new requests before anything is closed. The Task is named "pre-stop delay", and logs when the wait starts
+// and ends through the logger of the context, as LoggerFrom returns.
+// The wait ends early when the context is done, failing with its error; hence a second signal to RunOnSignal cuts it
+// short. The delay counts towards the grace period of WithHardKill, thus must be well shorter than it.
+func PreStopDelay(d time.Duration, gates ...*ReadinessGate) Task {
+	t := withCtx(func(ctx context.Context) error {
+		for _, g := range gates {
+			if g != nil {
+				g.NotReady()
+			}
+		}
+		logger, start := LoggerFrom(ctx), now().Now()
+		logger.Info("grace: pre-stop delay started", "delay", d)
+		if err := sleep(ctx, d); err != nil {
+			logger.Warn("grace: pre-stop delay cut short", "waited", now().Now().Sub(start), "err", err)
+			return err
+		}
+		logger.Info("grace: pre-stop delay ended", "waited", now().Now().Sub(start))
+		return nil
+	})
+	t.name, t.await = "pre-stop delay", true
+	return t
+}
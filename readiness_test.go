@@ -0,0 +1,102 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadinessGate_MustServeReadiness(t *testing.T) {
+	t.Parallel()
+	g := &ReadinessGate{}
+	serve := func() (int, string) {
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	code, body := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "not ready\n", body)
+
+	g.Ready()
+	assert.True(t, g.IsReady())
+	code, body = serve()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok\n", body)
+
+	g.NotReady()
+	assert.False(t, g.IsReady())
+}
+
+func TestReadinessGate_MarkNotReadyAndWait_MustFlipThenWait(t *testing.T) {
+	t.Parallel()
+	g := &ReadinessGate{}
+	g.Ready()
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() { // reads concurrently as the health endpoint does
+		defer wg.Done()
+		for g.IsReady() {
+		}
+	}()
+
+	start := time.Now()
+	assert.NoError(t, g.MarkNotReadyAndWait(time.Millisecond*20).Run(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*20)
+	assert.False(t, g.IsReady())
+	wg.Wait()
+}
+
+func TestReadinessGate_MarkNotReadyAndWait_MustReturnPromptly_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	g := &ReadinessGate{}
+	g.Ready()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	start := time.Now()
+	assert.ErrorIs(t, g.MarkNotReadyAndWait(time.Hour).Run(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, g.IsReady())
+}
+
+func TestPreStopDelay_MustMarkNotReady_ThenWait(t *testing.T) {
+	t.Parallel()
+	g1, g2 := &ReadinessGate{}, &ReadinessGate{}
+	g1.Ready()
+	g2.Ready()
+	buf := &bytes.Buffer{}
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(buf, nil)))
+	var readyOnClose bool
+	tsk := PreStopDelay(time.Millisecond*20, g1, nil, g2).Then(WithNoErr(func() { readyOnClose = g1.IsReady() || g2.IsReady() }))
+
+	start := time.Now()
+	report, err := NewRunner().RunReport(ctx, tsk)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*20)
+	assert.False(t, readyOnClose)
+	assert.Equal(t, "pre-stop delay", report.Steps[0].Name)
+	assert.Contains(t, buf.String(), `msg="grace: pre-stop delay started" step_index=0 step="pre-stop delay" delay=20ms`)
+	assert.Contains(t, buf.String(), `msg="grace: pre-stop delay ended"`)
+}
+
+func TestPreStopDelay_MustEndEarly_WhenContextIsDone(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(buf, nil)))
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer cancel()
+
+	start := time.Now()
+	err := PreStopDelay(time.Hour).Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Contains(t, buf.String(), `msg="grace: pre-stop delay cut short"`)
+}
@@ -0,0 +1,54 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRegistryShutdown is returned from Registry.Defer, when the Registry has been shut down.
+var ErrRegistryShutdown = errors.New("registry shutdown")
+
+// Registry collects cleanup tasks registered by modules as they initialize, to run them in reverse order on shutdown
+// like defer statements. Zero value of Registry is ready to use, and it is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	hooks    []Task
+	shutdown bool
+}
+
+// Defer registers given Task to run on Shutdown, before those registered earlier.
+// It returns ErrRegistryShutdown when Shutdown has been called, without registering the Task.
+func (r *Registry) Defer(t Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		return ErrRegistryShutdown
+	}
+	if !isNil(t) {
+		r.hooks = append(r.hooks, t)
+	}
+	return nil
+}
+
+// Shutdown runs every each Task registered in reverse order of registration, regardless of errors from others,
+// then returns their errors joined. Tasks run as RunSync does, on the goroutine of the caller.
+// When the context is done, the rest of tasks never run, and the error of the context is joined instead.
+// Tasks run only once; Shutdown called again returns nil.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	hooks := r.hooks
+	r.hooks, r.shutdown = nil, true
+	r.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := ctxErr(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := RunSync(ctx, hooks[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRegistry_Shutdown_MustRunInReverseOrder(t *testing.T) {
+	t.Parallel()
+	var order []string
+	r := &Registry{}
+	for _, name := range []string{"db", "cache", "http"} {
+		name := name
+		assert.NoError(t, r.Defer(Named(name, WithNoErr(func() { order = append(order, name) }))))
+	}
+	assert.NoError(t, r.Defer(nil))
+
+	assert.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, []string{"http", "cache", "db"}, order)
+	assert.NoError(t, r.Shutdown(context.Background()))
+	assert.Len(t, order, 3, "must run only once")
+}
+
+func TestRegistry_Shutdown_MustRunEveryEach_AndJoinErrors(t *testing.T) {
+	t.Parallel()
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	ran := false
+	r := &Registry{}
+	assert.NoError(t, r.Defer(With(func() error { return e1 })))
+	assert.NoError(t, r.Defer(WithNoErr(func() { ran = true })))
+	assert.NoError(t, r.Defer(WithNoErr(func() { panic(e2) })))
+
+	err := r.Shutdown(context.Background())
+	assert.ErrorIs(t, err, e1)
+	assert.ErrorIs(t, err, e2)
+	assert.True(t, ran)
+}
+
+func TestRegistry_Defer_MustReject_AfterShutdown(t *testing.T) {
+	t.Parallel()
+	r := &Registry{}
+	assert.NoError(t, r.Shutdown(context.Background()))
+	assert.ErrorIs(t, r.Defer(With(nil)), ErrRegistryShutdown)
+}
+
+func TestRegistry_Shutdown_MustStop_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	r := &Registry{}
+	assert.NoError(t, r.Defer(WithNoErr(func() { ran = true })))
+	assert.NoError(t, r.Defer(WithNoErr(cancel)))
+
+	assert.ErrorIs(t, r.Shutdown(ctx), context.Canceled)
+	assert.False(t, ran)
+}
+
+func TestRegistry_Defer_MustBeSafe_ForConcurrentUse(t *testing.T) {
+	t.Parallel()
+	r := &Registry{}
+	mu := sync.Mutex{}
+	var names []string
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			assert.NoError(t, r.Defer(WithNoErr(func() {
+				mu.Lock()
+				defer mu.Unlock()
+				names = append(names, name)
+			})))
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+	assert.NoError(t, r.Shutdown(context.Background()))
+	assert.Len(t, names, 50)
+}
@@ -0,0 +1,122 @@
+package grace
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// ReloadPolicy tells what a reload signal does while a reload is running. See WithReloadPolicy.
+type ReloadPolicy int
+
+const (
+	// ReloadQueue runs another reload after the running one. Signals in the meantime are coalesced into the one.
+	ReloadQueue ReloadPolicy = iota
+	// ReloadDrop ignores the signal.
+	ReloadDrop
+)
+
+// WithReload returns RunOption that makes RunOnSignal run given reload Task on any of given signals, such as
+// syscall.SIGHUP, and keep waiting for another signal rather than shutting down. Reloads never run concurrently,
+// and an error of a reload is given to onError, if any, which never stops the process.
+// A reload is run as Task.Run does, without options of the Runner such as WithHardKill.
+// A reload running when the shutdown begins is canceled and awaited first, as long as Task.Run waits for it, unless
+// another signal forces to quit waiting. Reload signals are ignored afterwards.
+func WithReload(t Task, onError func(error), signals ...os.Signal) RunOption {
+	return func(r *Runner) {
+		if isNil(t) || len(signals) == 0 {
+			return
+		}
+		policy := ReloadQueue
+		if r.reload != nil {
+			policy = r.reload.policy
+		}
+		r.reload = &reload{task: t, onError: onError, signals: signals, policy: policy}
+	}
+}
+
+// WithReloadPolicy returns RunOption that sets what a reload signal does while a reload is running, ReloadQueue by
+// default. It applies to WithReload given either before or after it.
+func WithReloadPolicy(policy ReloadPolicy) RunOption {
+	return func(r *Runner) {
+		if r.reload == nil {
+			r.reload = &reload{}
+		}
+		r.reload.policy = policy
+	}
+}
+
+// reload is the configuration of WithReload. Nil reload matches no signal.
+type reload struct {
+	task    Task
+	onError func(error)
+	signals []os.Signal
+	policy  ReloadPolicy
+}
+
+// matches tells whether given signal triggers a reload.
+func (c *reload) matches(sig os.Signal) bool {
+	if c == nil || c.task == nil {
+		return false
+	}
+	for _, s := range c.signals {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
+
+// reloader serializes reloads of a RunOnSignal.
+type reloader struct {
+	cfg    *reload
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running bool
+	queued  bool
+}
+
+func newReloader(ctx context.Context, cfg *reload) *reloader {
+	ctx, cancel := context.WithCancel(ctx)
+	return &reloader{cfg: cfg, ctx: ctx, cancel: cancel}
+}
+
+// trigger starts a reload, or queues or drops it by the policy while another one is running.
+func (l *reloader) trigger() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running {
+		l.queued = l.cfg.policy == ReloadQueue
+		return
+	}
+	l.running = true
+	l.wg.Add(1)
+	go l.loop()
+}
+
+// loop runs reloads until none is queued.
+func (l *reloader) loop() {
+	defer l.wg.Done()
+	for {
+		if err := l.cfg.task.Run(l.ctx); err != nil && l.cfg.onError != nil {
+			_ = guard(func() error { l.cfg.onError(err); return nil })
+		}
+		l.mu.Lock()
+		if !l.queued || l.ctx.Err() != nil {
+			l.running, l.queued = false, false
+			l.mu.Unlock()
+			return
+		}
+		l.queued = false
+		l.mu.Unlock()
+	}
+}
+
+// stop cancels the reload running, if any, then waits for it.
+func (l *reloader) stop() {
+	l.cancel()
+	l.wg.Wait()
+}
@@ -0,0 +1,146 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSignal is a synthetic signal, as those for reloads such as syscall.SIGHUP are missing on some platforms.
+type testSignal string
+
+func (s testSignal) String() string { return string(s) }
+func (testSignal) Signal()          {}
+
+// sigReload is the signal for reloads in tests.
+const sigReload = testSignal("reload")
+
+func TestRunner_RunOnSignal_MustReload_AndKeepWaiting_OnReloadSignal(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	reloads := &atomic.Int32{}
+	reloaded := make(chan struct{}, 1)
+	runner := NewRunner(WithReload(WithNoErr(func() {
+		reloads.Add(1)
+		reloaded <- struct{}{}
+	}), nil, sigReload))
+	go func() {
+		signals <- sigReload
+		<-reloaded
+		signals <- os.Interrupt
+	}()
+
+	report, err := runner.RunOnSignal(context.Background(), signals, Named("close", With(nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, int32(1), reloads.Load())
+}
+
+func TestReloader_MustQueueReload_WhileReloading(t *testing.T) {
+	t.Parallel()
+	reloads := &atomic.Int32{}
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	l := newReloader(context.Background(), &reload{task: WithNoErr(func() {
+		reloads.Add(1)
+		started <- struct{}{}
+		<-release
+	})})
+
+	l.trigger()
+	<-started
+	for i := 0; i < 3; i++ { // coalesced into one
+		l.trigger()
+	}
+	close(release)
+	<-started
+	l.stop()
+	assert.Equal(t, int32(2), reloads.Load())
+}
+
+func TestRunner_RunOnSignal_MustDropReload_WhileReloading_WithReloadDrop(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal)
+	reloads := &atomic.Int32{}
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	runner := NewRunner(WithReloadPolicy(ReloadDrop), WithReload(WithNoErr(func() {
+		reloads.Add(1)
+		started <- struct{}{}
+		<-release
+	}), nil, sigReload))
+	go func() {
+		signals <- sigReload
+		<-started
+		signals <- sigReload    // received while reloading, as the channel is unbuffered
+		signals <- os.Interrupt // received once the former is dropped
+		close(release)
+	}()
+
+	_, err := runner.RunOnSignal(context.Background(), signals, With(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), reloads.Load())
+}
+
+func TestRunner_RunOnSignal_MustReportReloadError_WithoutShutdown(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	failure := errors.New("failure")
+	errs := make(chan error, 1)
+	runner := NewRunner(WithReload(With(func() error { return failure }), func(err error) { errs <- err }, sigReload))
+	go func() {
+		signals <- sigReload
+		assert.ErrorIs(t, <-errs, failure)
+		signals <- os.Interrupt
+	}()
+
+	_, err := runner.RunOnSignal(context.Background(), signals, With(nil))
+	assert.NoError(t, err)
+}
+
+func TestRunner_RunOnSignal_MustCancelReload_BeforeShutdown(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	started := make(chan struct{})
+	reloadEnded := &atomic.Bool{}
+	runner := NewRunner(WithReload(WithCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		reloadEnded.Store(true)
+		return ctx.Err()
+	}), nil, sigReload))
+	go func() {
+		signals <- sigReload
+		<-started
+		signals <- os.Interrupt
+	}()
+
+	var shutdownAfterReload bool
+	_, err := runner.RunOnSignal(context.Background(), signals, WithNoErr(func() { shutdownAfterReload = reloadEnded.Load() }))
+	assert.NoError(t, err)
+	assert.True(t, shutdownAfterReload)
+}
+
+func TestRunner_RunOnSignal_MustForceQuit_WhileStoppingStuckReload(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal)
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	runner := NewRunner(WithReload(Shield(WithNoErr(func() {
+		close(started)
+		<-release // ignores the cancellation
+	}), time.Hour), nil, sigReload))
+	go func() {
+		signals <- sigReload
+		<-started
+		signals <- os.Interrupt
+		signals <- os.Interrupt // while stopping the reload
+	}()
+
+	shutdown := &atomic.Bool{}
+	_, err := runner.RunOnSignal(context.Background(), signals, WithNoErr(func() { shutdown.Store(true) }))
+	assert.ErrorIs(t, err, ErrForceQuit)
+	assert.False(t, shutdown.Load(), "must run the shutdown canceled")
+}
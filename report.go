@@ -0,0 +1,425 @@
+package grace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StepStatus is a status of a task in the chain, as a result of a run.
+type StepStatus int
+
+const (
+	// StepNotReached is a status of a task which the run never reached.
+	StepNotReached StepStatus = iota
+	// StepSucceeded is a status of a task which returned no error.
+	StepSucceeded
+	// StepFailed is a status of a task which returned an error.
+	StepFailed
+	// StepPanicked is a status of a task which panicked.
+	StepPanicked
+	// StepSkipped is a status of a task which the run has passed by without executing.
+	StepSkipped
+)
+
+var stepStatusNames = [...]string{"not-reached", "succeeded", "failed", "panicked", "skipped"}
+
+// String implements fmt.Stringer
+func (s StepStatus) String() string {
+	if s < 0 || int(s) >= len(stepStatusNames) {
+		return "unknown"
+	}
+	return stepStatusNames[s]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s StepStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// StepReport describes how a task in the chain went.
+type StepReport struct {
+	// Index of the task in the chain, starting from zero.
+	Index int
+	// Name of the task, empty if not named.
+	Name string
+	// Tags of the task, as given by WithTags. Nil if none; must not be modified.
+	Tags map[string]string
+	// Start is the time when the task started. Zero if not started.
+	Start time.Time
+	// Duration the task took.
+	Duration time.Duration
+	// Paused is how long the run has been paused right before the task started, excluded from Duration.
+	Paused time.Duration
+	// Heartbeat is the time when the task has called Heartbeat last. Zero if never called.
+	Heartbeat time.Time
+	// Attempts is the number of attempts made by retries of the task, such as WithRetry. Zero if not retried.
+	Attempts int
+	// Status of the task.
+	Status StepStatus
+	// Err is the error from the task, including the one converted from panic.
+	Err error
+}
+
+// label returns the name of the task, or its index such as "#1" if not named.
+func (s StepReport) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return "#" + strconv.Itoa(s.Index)
+}
+
+// MarshalJSON implements json.Marshaler
+func (s StepReport) MarshalJSON() ([]byte, error) {
+	type step struct {
+		Index      int        `json:"index"`
+		Name       string     `json:"name,omitempty"`
+		Start      *time.Time `json:"start,omitempty"`
+		Heartbeat  *time.Time `json:"heartbeat,omitempty"`
+		DurationMS float64    `json:"duration_ms"`
+		PausedMS   float64    `json:"paused_ms,omitempty"`
+		Attempts   int        `json:"attempts,omitempty"`
+		Status     StepStatus `json:"status"`
+		Success    bool       `json:"success"`
+		Error      string     `json:"error,omitempty"`
+	}
+	out := step{
+		Index:      s.Index,
+		Name:       s.Name,
+		DurationMS: float64(s.Duration) / float64(time.Millisecond),
+		PausedMS:   float64(s.Paused) / float64(time.Millisecond),
+		Attempts:   s.Attempts,
+		Status:     s.Status,
+		Success:    s.Status == StepSucceeded,
+	}
+	if !s.Start.IsZero() {
+		out.Start = &s.Start
+	}
+	if !s.Heartbeat.IsZero() {
+		out.Heartbeat = &s.Heartbeat
+	}
+	if s.Err != nil {
+		out.Error = s.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// Report describes how every each task in the chain went during a run.
+type Report struct {
+	// Steps in order of the chain, including those not reached.
+	Steps []StepReport `json:"steps"`
+
+	chain Task // the chain reported
+}
+
+// Totals summarizes every each task of a Report.
+type Totals struct {
+	// Steps is the number of tasks in the chain.
+	Steps int `json:"steps"`
+	// Succeeded is the number of tasks succeeded.
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of tasks failed, including those panicked.
+	Failed int `json:"failed"`
+	// Skipped is the number of tasks skipped.
+	Skipped int `json:"skipped"`
+	// NotReached is the number of tasks never reached.
+	NotReached int `json:"not_reached"`
+	// Duration is the sum of durations of tasks.
+	Duration time.Duration `json:"-"`
+}
+
+// Totals returns the summary of every each task.
+func (r Report) Totals() Totals {
+	totals := Totals{Steps: len(r.Steps)}
+	for _, s := range r.Steps {
+		totals.Duration += s.Duration
+		switch s.Status {
+		case StepSucceeded:
+			totals.Succeeded++
+		case StepFailed, StepPanicked:
+			totals.Failed++
+		case StepSkipped:
+			totals.Skipped++
+		default:
+			totals.NotReached++
+		}
+	}
+	return totals
+}
+
+// MarshalJSON implements json.Marshaler, which includes Totals as well.
+func (r Report) MarshalJSON() ([]byte, error) {
+	type totals struct {
+		Totals
+		DurationMS float64 `json:"duration_ms"`
+	}
+	t := r.Totals()
+	steps := r.Steps
+	if steps == nil {
+		steps = []StepReport{}
+	}
+	return json.Marshal(struct {
+		Steps  []StepReport `json:"steps"`
+		Totals totals       `json:"totals"`
+	}{steps, totals{t, float64(t.Duration) / float64(time.Millisecond)}})
+}
+
+// Outcome is the terminal status of a run.
+type Outcome int
+
+const (
+	// OutcomeCompleted is an outcome of a run which has passed every each task without failure.
+	OutcomeCompleted Outcome = iota
+	// OutcomeFailed is an outcome of a run which a task has failed.
+	OutcomeFailed
+	// OutcomeCanceled is an outcome of a run which has stopped as its context is canceled or its deadline exceeded.
+	OutcomeCanceled
+	// OutcomePanicked is an outcome of a run which a task has panicked.
+	OutcomePanicked
+)
+
+var outcomeNames = [...]string{"completed", "failed", "canceled", "panicked"}
+
+// String implements fmt.Stringer
+func (o Outcome) String() string {
+	if o < 0 || int(o) >= len(outcomeNames) {
+		return "unknown"
+	}
+	return outcomeNames[o]
+}
+
+// Outcome returns the terminal status of the run, along with the index of the task where the run has stopped,
+// or -1 when completed. Those stopped by ErrStopChain or skipped by ErrSkippedDeadline are considered as completed.
+func (r Report) Outcome() (Outcome, int) {
+	for _, s := range r.Steps {
+		switch {
+		case s.Status == StepPanicked:
+			return OutcomePanicked, s.Index
+		case s.Status == StepFailed && isContextErr(s.Err):
+			return OutcomeCanceled, s.Index
+		case s.Status == StepFailed:
+			return OutcomeFailed, s.Index
+		case s.Status == StepNotReached: // stopped between tasks, only by the context
+			return OutcomeCanceled, s.Index
+		}
+	}
+	return OutcomeCompleted, -1
+}
+
+// Remainder returns the rest of the chain of given Report, starting from the first task that has not completed,
+// so that a failed run can be resumed without repeating tasks already completed.
+// Tasks skipped by ErrStopChain are considered as completed, while those skipped by ErrSkippedDeadline are not.
+// When every each task has completed, it returns a Task that does nothing.
+func Remainder(report Report) Task {
+	t := report.chain
+	for _, s := range report.Steps {
+		if isNil(t) || !s.completed() {
+			break
+		}
+		t = t.Next()
+	}
+	if isNil(t) {
+		return With(nil)
+	}
+	return t
+}
+
+// ResumeFrom returns the rest of given chain starting from the task of given index, skipping those before it,
+// such as the Index of StepError to resume a failed run from the failed task. Unlike Remainder, it needs no Report.
+// When the index is beyond the chain, it returns a Task that does nothing.
+func ResumeFrom(t Task, index int) Task {
+	for ; index > 0 && !isNil(t); index-- {
+		t = t.Next()
+	}
+	if isNil(t) {
+		return With(nil)
+	}
+	return t
+}
+
+// completed tells whether the task has nothing left to be done by the run.
+func (s StepReport) completed() bool {
+	return s.Status == StepSucceeded || (s.Status == StepSkipped && s.Err == nil)
+}
+
+// recorder records progress of a run. Nil recorder ignores everything, thus costs nothing.
+type recorder struct {
+	mu      sync.Mutex
+	steps   []StepReport
+	sealed  bool // a run returned, while its step may still be running in background
+	stopped bool // a step stopped the chain by ErrStopChain
+
+	observers []Observer // notified of every each task started and ended
+}
+
+// start records that the task of given index has started, after the run has been paused for given duration.
+func (r *recorder) start(info StepInfo, paused time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	sealed := r.sealed
+	if !sealed {
+		r.steps = append(r.steps, StepReport{Index: info.Index, Name: info.Name, Tags: info.Tags, Start: time.Now(), Paused: paused})
+	}
+	r.mu.Unlock()
+	if !sealed {
+		notify(r.observers, func(o Observer) { o.OnStepStart(info) })
+	}
+}
+
+// end records the result of the task of given index.
+func (r *recorder) end(index int, panicked bool, err error) {
+	if r == nil {
+		return
+	}
+	end := time.Now()
+	r.mu.Lock()
+	if r.sealed || index >= len(r.steps) {
+		r.mu.Unlock()
+		return
+	}
+	s := &r.steps[index]
+	s.Duration, s.Err = end.Sub(s.Start), err
+	switch {
+	case panicked:
+		s.Status = StepPanicked
+	case err != nil:
+		s.Status = StepFailed
+	default:
+		s.Status = StepSucceeded
+	}
+	info := StepInfo{Index: index, Name: s.Name, Tags: s.Tags}
+	r.mu.Unlock()
+
+	var panicErr *PanicError
+	if panicked && errors.As(err, &panicErr) {
+		notify(r.observers, func(o Observer) { o.OnPanic(info, panicErr.Value, panicErr.Stack) })
+	}
+	notify(r.observers, func(o Observer) { o.OnStepEnd(info, err) })
+}
+
+// abandon seals the recorder as the run returns with given error, leaving the task in progress, if any, which is
+// notified of as ended with the error, so that every each start is paired with an end.
+func (r *recorder) abandon(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	abandoned := !r.sealed && len(r.steps) > 0 && r.steps[len(r.steps)-1].Status == StepNotReached
+	r.sealed = true
+	var info StepInfo
+	if abandoned {
+		s := r.steps[len(r.steps)-1]
+		info = StepInfo{Index: s.Index, Name: s.Name, Tags: s.Tags}
+	}
+	r.mu.Unlock()
+	if abandoned {
+		notify(r.observers, func(o Observer) { o.OnStepEnd(info, err) })
+	}
+}
+
+// stepCtx returns the context for the step of given index, which records Heartbeat and attempts from the step.
+func (r *recorder) stepCtx(ctx context.Context, index int) context.Context {
+	if r == nil {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, attemptKey{}, func() { r.attempt(index) })
+	return withHeartbeat(ctx, func() { r.beat(index) })
+}
+
+// attempt records that the task of given index has made an attempt of retries.
+func (r *recorder) attempt(index int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.sealed && index < len(r.steps) {
+		r.steps[index].Attempts++
+	}
+}
+
+// beat records that the task of given index has called Heartbeat.
+func (r *recorder) beat(index int) {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.sealed && index < len(r.steps) {
+		r.steps[index].Heartbeat = now
+	}
+}
+
+// skip records that the task of given index has been skipped for given reason.
+func (r *recorder) skip(info StepInfo, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.sealed {
+		r.steps = append(r.steps, StepReport{Index: info.Index, Name: info.Name, Tags: info.Tags, Status: StepSkipped, Err: err})
+	}
+}
+
+// current returns the index and name of the task started last, if any.
+func (r *recorder) current() (index int, name string, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.steps) == 0 {
+		return 0, "", false
+	}
+	last := r.steps[len(r.steps)-1]
+	return last.Index, last.Name, true
+}
+
+// completed returns labels of tasks succeeded thus far.
+func (r *recorder) completed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var labels []string
+	for _, s := range r.steps {
+		if s.Status == StepSucceeded {
+			labels = append(labels, s.label())
+		}
+	}
+	return labels
+}
+
+// stop records that the chain has been stopped by ErrStopChain, thus the rest is skipped.
+func (r *recorder) stop() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+}
+
+// report seals the recorder, then returns Report of given chain which the recorder has run.
+// A task left running is reported as failed with the error of given context.
+func (r *recorder) report(t Task, err error) Report {
+	r.mu.Lock()
+	r.sealed = true
+	steps := make([]StepReport, len(r.steps))
+	copy(steps, r.steps)
+	rest := StepNotReached
+	if r.stopped {
+		rest = StepSkipped
+	}
+	r.mu.Unlock()
+
+	if n := len(steps); n > 0 && steps[n-1].Status == StepNotReached { // abandoned in the middle
+		s := &steps[n-1]
+		s.Duration, s.Status, s.Err = time.Since(s.Start), StepFailed, err
+	}
+	func() {
+		defer func() { _ = recover() }() // the run has failed already by the panic from Next of others
+		for index, tt := 0, t; !isNil(tt); index, tt = index+1, tt.Next() {
+			if index >= len(steps) {
+				steps = append(steps, StepReport{Index: index, Name: nameOf(tt), Tags: tagsOf(tt), Status: rest})
+			}
+		}
+	}()
+	return Report{Steps: steps, chain: t}
+}
@@ -0,0 +1,220 @@
+package grace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTask_RunReport_MustDescribeEveryStep(t *testing.T) {
+	t.Parallel()
+	tsk := Named("first", With(nil)).
+		Then(With(func() error { return errors.New("failure") })).
+		Then(Named("last", With(nil)))
+
+	report, err := tsk.RunReport(context.Background())
+	assert.ErrorContains(t, err, "failure")
+	assert.Len(t, report.Steps, 3)
+
+	assert.Equal(t, 0, report.Steps[0].Index)
+	assert.Equal(t, "first", report.Steps[0].Name)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.False(t, report.Steps[0].Start.IsZero())
+	assert.NoError(t, report.Steps[0].Err)
+
+	assert.Equal(t, 1, report.Steps[1].Index)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+	assert.ErrorContains(t, report.Steps[1].Err, "failure")
+
+	assert.Equal(t, StepReport{Index: 2, Name: "last", Status: StepNotReached}, report.Steps[2])
+}
+
+func TestTask_RunReport_MustReportPanic(t *testing.T) {
+	t.Parallel()
+	report, err := WithNoErr(func() { panic("panicked") }).RunReport(context.Background())
+	assert.ErrorContains(t, err, "panicked")
+	assert.Equal(t, StepPanicked, report.Steps[0].Status)
+	assert.ErrorContains(t, report.Steps[0].Err, "panicked")
+}
+
+func TestTask_RunReport_MustReportAbandonedStep_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	tsk := With(nil).Then(WithNoErr(func() { time.Sleep(time.Second) })).Then(With(nil))
+
+	report, err := tsk.RunReport(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+	assert.ErrorIs(t, report.Steps[1].Err, context.DeadlineExceeded)
+	assert.Equal(t, StepNotReached, report.Steps[2].Status)
+}
+
+func TestReport_MustMarshalJSON(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 8, 30, 0, 0, 0, 0, time.UTC)
+	report := Report{Steps: []StepReport{
+		{Index: 0, Name: "stop-http", Start: start, Duration: time.Millisecond * 1500, Status: StepSucceeded},
+		{Index: 1, Start: start, Duration: time.Millisecond, Status: StepFailed, Err: errors.New("failure")},
+		{Index: 2, Name: "close-db"},
+	}}
+
+	b, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"steps": [
+		{"index": 0, "name": "stop-http", "start": "2022-08-30T00:00:00Z", "duration_ms": 1500, "status": "succeeded", "success": true},
+		{"index": 1, "start": "2022-08-30T00:00:00Z", "duration_ms": 1, "status": "failed", "success": false, "error": "failure"},
+		{"index": 2, "name": "close-db", "duration_ms": 0, "status": "not-reached", "success": false}
+	], "totals": {"steps": 3, "succeeded": 1, "failed": 1, "skipped": 0, "not_reached": 1, "duration_ms": 1501}}`, string(b))
+}
+
+func TestStepStatus_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "not-reached", StepNotReached.String())
+	assert.Equal(t, "succeeded", StepSucceeded.String())
+	assert.Equal(t, "failed", StepFailed.String())
+	assert.Equal(t, "panicked", StepPanicked.String())
+	assert.Equal(t, "skipped", StepSkipped.String())
+	assert.Equal(t, "unknown", StepStatus(-1).String())
+}
+
+func TestRemainder_MustResumeFromFailedStep(t *testing.T) {
+	t.Parallel()
+	counts, broken := make([]int, 5), true
+	b := &Builder{}
+	for i := range counts {
+		i := i
+		b.Add(func() error {
+			if i == 2 && broken {
+				return errors.New("failure")
+			}
+			counts[i]++
+			return nil
+		})
+	}
+	tsk := b.Task()
+
+	report, err := tsk.RunReport(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []int{1, 1, 0, 0, 0}, counts)
+
+	broken = false
+	rest := Remainder(report)
+	assert.Equal(t, 3, Count(rest))
+	assert.NoError(t, rest.Run(context.Background()))
+	assert.Equal(t, []int{1, 1, 1, 1, 1}, counts)
+}
+
+func TestRemainder_MustReturnNoop_WhenCompleted(t *testing.T) {
+	t.Parallel()
+	count := 0
+	step := WithNoErr(func() { count++ })
+
+	report, err := step.Then(With(func() error { return ErrStopChain })).Then(step).RunReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, Count(Remainder(report)))
+	assert.NoError(t, Remainder(report).Run(context.Background()))
+	assert.Equal(t, 1, count)
+
+	assert.NoError(t, Remainder(Report{}).Run(context.Background()))
+}
+
+func TestReport_MustMarshalJSON_AfterMixedRun(t *testing.T) {
+	t.Parallel()
+	tsk := Named("ok", With(nil)).
+		Then(Named("panic", WithNoErr(func() { panic("nil map") }))).
+		Then(Named("never", With(nil)))
+	report, err := tsk.RunReport(context.Background())
+	assert.Error(t, err)
+
+	b, err := json.Marshal(report)
+	assert.NoError(t, err)
+	var decoded struct {
+		Steps []struct {
+			Name    string `json:"name"`
+			Success bool   `json:"success"`
+			Status  string `json:"status"`
+			Error   string `json:"error"`
+		} `json:"steps"`
+		Totals map[string]float64 `json:"totals"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Len(t, decoded.Steps, 3)
+	assert.True(t, decoded.Steps[0].Success)
+	assert.False(t, decoded.Steps[1].Success)
+	assert.Equal(t, "panicked", decoded.Steps[1].Status)
+	assert.Equal(t, "nil map", decoded.Steps[1].Error)
+	assert.Equal(t, "never", decoded.Steps[2].Name)
+	assert.Equal(t, 3.0, decoded.Totals["steps"])
+	assert.Equal(t, 1.0, decoded.Totals["succeeded"])
+	assert.Equal(t, 1.0, decoded.Totals["failed"])
+	assert.Equal(t, 1.0, decoded.Totals["not_reached"])
+
+	b, err = json.Marshal(Report{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"steps": [], "totals": {"steps": 0, "succeeded": 0, "failed": 0, "skipped": 0, "not_reached": 0, "duration_ms": 0}}`, string(b))
+}
+
+func TestReport_Outcome_MustTellTerminalStatus(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scenarios := []struct {
+		name    string
+		ctx     context.Context
+		task    Task
+		outcome Outcome
+		index   int
+	}{
+		{"completed", context.Background(), With(nil).Then(With(nil)), OutcomeCompleted, -1},
+		{"stopped", context.Background(), With(func() error { return ErrStopChain }).Then(With(nil)), OutcomeCompleted, -1},
+		{"failed", context.Background(), With(nil).Then(With(func() error { return failure })), OutcomeFailed, 1},
+		{"panicked", context.Background(), With(nil).Then(WithNoErr(func() { panic(failure) })), OutcomePanicked, 1},
+		{"canceled between", ctx, WithNoErr(cancel).Then(With(nil)), OutcomeCanceled, 1},
+		{"canceled within", context.Background(), WithCtx(func(context.Context) error { return context.Canceled }),
+			OutcomeCanceled, 0},
+	}
+	for _, s := range scenarios {
+		report, _ := s.task.RunReport(s.ctx)
+		outcome, index := report.Outcome()
+		assert.Equal(t, s.outcome, outcome, s.name)
+		assert.Equal(t, s.index, index, s.name)
+	}
+	assert.Equal(t, "panicked", OutcomePanicked.String())
+	assert.Equal(t, "unknown", Outcome(-1).String())
+}
+
+func TestResumeFrom_MustSkipTasksBeforeIndex(t *testing.T) {
+	t.Parallel()
+	var ran []string
+	broken := true
+	step := func(name string) Task {
+		return Named(name, WithNoErr(func() { ran = append(ran, name) }))
+	}
+	tsk := step("a").Then(step("b")).Then(Named("c", With(func() error {
+		if broken {
+			return errors.New("broken")
+		}
+		ran = append(ran, "c")
+		return nil
+	}))).Then(step("d"))
+
+	err := tsk.Run(context.Background())
+	var stepErr *StepError
+	if !assert.ErrorAs(t, err, &stepErr) {
+		return
+	}
+	broken, ran = false, nil
+	assert.NoError(t, ResumeFrom(tsk, stepErr.Index).Run(context.Background()))
+	assert.Equal(t, []string{"c", "d"}, ran)
+
+	assert.Equal(t, 4, Count(ResumeFrom(tsk, -1)))
+	assert.Equal(t, 1, Count(ResumeFrom(tsk, 3)))
+	assert.Equal(t, 1, Count(ResumeFrom(tsk, 10)))
+	assert.NoError(t, ResumeFrom(nil, 0).Run(context.Background()))
+}
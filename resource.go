@@ -0,0 +1,29 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// WithResource returns new Task that opens a resource, then runs the chain built by use with the resource,
+// and closes the resource once the chain finishes, no matter whether it failed, panicked, or got canceled.
+// An error from Close is joined with the error of the chain. When open fails, use is never called.
+// The chain runs as RunSync does, so that the resource is never closed while a step of the chain is still using it.
+func WithResource[R io.Closer](open func() (R, error), use func(resource R) Task) Task {
+	return withCtx(func(ctx context.Context) (err error) {
+		resource, err := open()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := resource.Close(); closeErr != nil {
+				err = errors.Join(err, closeErr)
+			}
+		}()
+		if use == nil {
+			return nil
+		}
+		return RunSync(ctx, use(resource))
+	})
+}
@@ -0,0 +1,84 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// resource is an io.Closer which counts Close.
+type resource struct {
+	closed int
+	err    error
+}
+
+func (r *resource) Close() error {
+	r.closed++
+	return r.err
+}
+
+func TestWithResource_MustClose_AfterChain(t *testing.T) {
+	t.Parallel()
+	res := &resource{}
+	var order []string
+	tsk := WithResource(func() (*resource, error) { return res, nil }, func(r *resource) Task {
+		return WithNoErr(func() { order = append(order, "use") }).
+			Then(WithNoErr(func() { assert.Zero(t, r.closed, "must not close while in use") }))
+	})
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 1, res.closed)
+	assert.Equal(t, []string{"use"}, order)
+}
+
+func TestWithResource_MustClose_WhenChainFailedOrPanicked(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	for _, use := range []func(*resource) Task{
+		func(*resource) Task { return With(func() error { return failure }) },
+		func(*resource) Task { return WithNoErr(func() { panic(failure) }) },
+		func(*resource) Task { panic(failure) },
+	} {
+		res := &resource{}
+		tsk := WithResource(func() (*resource, error) { return res, nil }, use)
+		assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+		assert.Equal(t, 1, res.closed)
+	}
+}
+
+func TestWithResource_MustClose_WhenCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	res := &resource{}
+	tsk := WithResource(func() (*resource, error) { return res, nil }, func(*resource) Task {
+		return WithNoErr(cancel).Then(With(nil))
+	})
+
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.Canceled)
+	assert.Equal(t, 1, res.closed)
+}
+
+func TestWithResource_MustJoinCloseError(t *testing.T) {
+	t.Parallel()
+	errClose, failure := errors.New("close"), errors.New("failure")
+	res := &resource{err: errClose}
+	open := func() (*resource, error) { return res, nil }
+
+	err := WithResource(open, func(*resource) Task { return With(nil) }).Run(context.Background())
+	assert.ErrorIs(t, err, errClose)
+
+	err = WithResource(open, func(*resource) Task { return With(func() error { return failure }) }).Run(context.Background())
+	assert.ErrorIs(t, err, errClose)
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestWithResource_MustNotUse_WhenOpenFailed(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := WithResource(func() (*resource, error) { return nil, failure }, func(*resource) Task {
+		t.Fatal("must not use")
+		return nil
+	})
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+}
@@ -0,0 +1,257 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BackoffConfig configures attempts and delays between them for WithBackoff.
+type BackoffConfig struct {
+	// Attempts is the maximum number of attempts including the first one. Values less than 1 are considered as 1.
+	Attempts int
+
+	// Base is the delay before the second attempt.
+	Base time.Duration
+
+	// Max caps the delay computed from Base and Multiplier, excluding jitter. Zero means no cap.
+	Max time.Duration
+
+	// Multiplier grows the delay per attempt. Values less than 1 are considered as 1, hence a constant delay.
+	Multiplier float64
+
+	// Jitter is a fraction of the delay, which a random portion of it is added to the delay.
+	Jitter float64
+
+	// Rand is a source of the jitter. Uses shared source when nil; set seeded one for a deterministic sequence.
+	Rand *rand.Rand
+
+	// AttemptEstimate is how long an attempt is expected to take. Retrying is abandoned when the context has less
+	// time remaining until its deadline than the delay plus the estimate.
+	AttemptEstimate time.Duration
+}
+
+// WithRetry returns new Task that retries given step with constant backoff between attempts, until the step
+// succeeds or attempts run out. The last error is returned in latter case. Waiting for backoff respects context,
+// and retrying is abandoned with ErrRetryAbandoned when the deadline of the context comes before the backoff ends.
+func WithRetry(step Step, attempts int, backoff time.Duration) Task {
+	return WithRetryIf(step, attempts, backoff, nil)
+}
+
+// WithRetryIf returns new Task that retries given step as same as WithRetry does, but only when retryable reports
+// the error is worth retrying. Otherwise, the error is returned immediately.
+// Nil retryable retries on any error, except those marked by Fatal as other retries do.
+func WithRetryIf(step Step, attempts int, backoff time.Duration, retryable func(error) bool) Task {
+	if step == nil {
+		return With(nil)
+	}
+	return withCtx(func(ctx context.Context) error {
+		return retry(ctx, step, attempts, func(int) time.Duration { return backoff }, retryable, 0)
+	})
+}
+
+// WithBackoff returns new Task that retries given step with delays between attempts, until the step succeeds
+// or attempts run out. The last error is returned in latter case. Waiting for the delay respects context.
+func WithBackoff(step Step, cfg BackoffConfig) Task {
+	if step == nil {
+		return With(nil)
+	}
+	var mu sync.Mutex // guards cfg.Rand, which is not safe for concurrent use
+	delay := func(attempt int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return cfg.delay(attempt)
+	}
+	return withCtx(func(ctx context.Context) error {
+		return retry(ctx, step, cfg.Attempts, delay, nil, cfg.AttemptEstimate)
+	})
+}
+
+// Backoff computes the delay to wait after an attempt, for WithRetryBackoff.
+// Implementations must be safe for concurrent use, as a Task can be run concurrently.
+type Backoff interface {
+	// Delay returns the duration to wait after given zero-based attempt.
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFunc is a function that implements Backoff.
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay implements Backoff.Delay
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// BackoffExponential returns Backoff that doubles the delay from base per attempt, capped by ceiling unless it is zero.
+// Jitter is a fraction of the delay, which the delay is randomly shifted by, either up or down,
+// so that instances retrying at the same time spread out.
+func BackoffExponential(base, ceiling time.Duration, jitter float64) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		d := float64(base) * math.Pow(2, float64(attempt))
+		if ceiling > 0 && d > float64(ceiling) {
+			d = float64(ceiling)
+		}
+		if jitter > 0 {
+			d += d * jitter * (2*rand.Float64() - 1)
+		}
+		return durationOf(d)
+	})
+}
+
+// durationOf converts given nanoseconds into Duration, saturating at the bounds rather than overflowing,
+// such as of a delay grown exponentially without a ceiling.
+func durationOf(d float64) time.Duration {
+	switch {
+	case math.IsNaN(d) || d <= 0: // NaN of zero base by infinite growth
+		return 0
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	default:
+		return time.Duration(d)
+	}
+}
+
+// WithRetryBackoff returns new Task that retries given step as same as WithRetry does, but waits for the delay
+// computed by given Backoff between attempts. Nil backoff retries without any delay.
+func WithRetryBackoff(step Step, attempts int, backoff Backoff) Task {
+	if step == nil {
+		return With(nil)
+	}
+	if backoff == nil {
+		backoff = BackoffFunc(func(int) time.Duration { return 0 })
+	}
+	return withCtx(func(ctx context.Context) error {
+		return retry(ctx, step, attempts, backoff.Delay, nil, 0)
+	})
+}
+
+// ErrRetryBudget is the error of retries given up, as the retry budget of the run is exhausted.
+// See WithRetryBudget.
+var ErrRetryBudget = errors.New("retry budget exhausted")
+
+// WithRetryBudget returns RunOption that bounds retries across every each task of a run, such as WithRetry,
+// by the total number of attempts including the first ones, and the total delay between attempts.
+// Once the budget is exhausted, a failure is returned immediately, wrapped by ErrRetryBudget.
+// Values less than 1 do not bound the dimension.
+func WithRetryBudget(maxTotalAttempts int, maxTotalDelay time.Duration) RunOption {
+	return func(r *Runner) {
+		r.budget = &retryBudget{maxAttempts: maxTotalAttempts, maxDelay: maxTotalDelay}
+	}
+}
+
+// retryBudget bounds retries of a run. Nil retryBudget bounds nothing.
+type retryBudget struct {
+	mu          sync.Mutex
+	attempts    int
+	delay       time.Duration
+	maxAttempts int
+	maxDelay    time.Duration
+}
+
+// attemptKey is the context key of the function that records an attempt of the step in progress.
+type attemptKey struct{}
+
+// budgetKey is the context key of the retry budget of a run.
+type budgetKey struct{}
+
+// fresh returns new budget of the same bounds, which nothing has been spent from.
+func (b *retryBudget) fresh() *retryBudget {
+	return &retryBudget{maxAttempts: b.maxAttempts, maxDelay: b.maxDelay}
+}
+
+// first records the first attempt of a retry, which is never bounded.
+func (b *retryBudget) first() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts++
+}
+
+// spend reserves another attempt after given delay, then tells whether the budget has allowed it.
+func (b *retryBudget) spend(delay time.Duration) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if (b.maxAttempts > 0 && b.attempts >= b.maxAttempts) || (b.maxDelay > 0 && delay > b.maxDelay-b.delay) {
+		return false
+	}
+	b.attempts++
+	b.delay += delay
+	return true
+}
+
+// ErrRetryAbandoned is the error of retries given up, as the deadline of the context is too close for another attempt.
+var ErrRetryAbandoned = errors.New("retry abandoned: insufficient deadline")
+
+// retry executes the step until it succeeds, attempts run out, or retryable reports an error is not retryable.
+// Nil retryable retries on any error but fatal ones.
+// Attempts are recorded to the report, and bounded by the retry budget of the run, if any.
+// It never waits for another attempt which cannot complete until the deadline of the context, as estimated.
+func retry(ctx context.Context, step Step, attempts int, delay func(attempt int) time.Duration, retryable func(error) bool, estimate time.Duration) error {
+	budget, _ := ctx.Value(budgetKey{}).(*retryBudget)
+	record, ok := ctx.Value(attemptKey{}).(func())
+	if !ok {
+		record = func() {}
+	}
+	if retryable == nil {
+		retryable = func(err error) bool { return !IsFatal(err) }
+	}
+	budget.first()
+	for attempt := 0; ; attempt++ {
+		record()
+		err := step()
+		if err == nil || attempt+1 >= attempts || !retryable(err) {
+			return err
+		}
+		d := delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now().Now())-estimate < d {
+			return fmt.Errorf("%w: %w", ErrRetryAbandoned, err)
+		}
+		if !budget.spend(d) {
+			return fmt.Errorf("%w: %w", ErrRetryBudget, err)
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// delay returns the duration to wait after given zero-based attempt.
+func (c BackoffConfig) delay(attempt int) time.Duration {
+	multiplier := math.Max(c.Multiplier, 1)
+	d := float64(c.Base) * math.Pow(multiplier, float64(attempt))
+	if c.Max > 0 && d > float64(c.Max) {
+		d = float64(c.Max)
+	}
+	if c.Jitter > 0 {
+		r := rand.Float64
+		if c.Rand != nil {
+			r = c.Rand.Float64
+		}
+		d += d * c.Jitter * r()
+	}
+	return durationOf(d)
+}
+
+// sleep waits for given duration, or returns context error when the context is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctxErr(ctx)
+	}
+	timer, stop := now().NewTimer(d)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		return ctxErr(ctx)
+	case <-timer:
+		return nil
+	}
+}
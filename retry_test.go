@@ -0,0 +1,314 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoffConfig_Delay_MustStayInBounds(t *testing.T) {
+	t.Parallel()
+	cfg := BackoffConfig{
+		Base:       time.Millisecond * 10,
+		Max:        time.Millisecond * 100,
+		Multiplier: 2,
+		Jitter:     0.5,
+		Rand:       rand.New(rand.NewSource(303)),
+	}
+	expected := []time.Duration{10, 20, 40, 80, 100, 100}
+	for attempt, base := range expected {
+		base *= time.Millisecond
+		d := cfg.delay(attempt)
+		assert.GreaterOrEqual(t, d, base, "attempt %d", attempt)
+		assert.Less(t, d, base+base/2, "attempt %d", attempt)
+	}
+}
+
+func TestBackoffConfig_Delay_MustBeDeterministic_WithSeed(t *testing.T) {
+	t.Parallel()
+	cfg := func() BackoffConfig {
+		return BackoffConfig{Base: time.Second, Multiplier: 3, Jitter: 0.2, Rand: rand.New(rand.NewSource(1))}
+	}
+	c1, c2 := cfg(), cfg()
+	for attempt := 0; attempt < 5; attempt++ {
+		assert.Equal(t, c1.delay(attempt), c2.delay(attempt))
+	}
+}
+
+func TestBackoffConfig_Delay_MustBeConstant_WhenNoMultiplier(t *testing.T) {
+	t.Parallel()
+	cfg := BackoffConfig{Base: time.Second}
+	assert.Equal(t, time.Second, cfg.delay(0))
+	assert.Equal(t, time.Second, cfg.delay(5))
+}
+
+func TestBackoffConfig_Delay_MustSaturate_WhenUncapped(t *testing.T) {
+	t.Parallel()
+	cfg := BackoffConfig{Base: time.Second, Multiplier: 2, Jitter: 0.5}
+	for _, attempt := range []int{40, 100, 2000} {
+		assert.Equal(t, time.Duration(math.MaxInt64), cfg.delay(attempt), attempt)
+	}
+	assert.Zero(t, BackoffConfig{Multiplier: 2}.delay(2000))
+	assert.Equal(t, time.Duration(math.MaxInt64), BackoffExponential(time.Second, 0, 0).Delay(2000))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cfg = BackoffConfig{Attempts: 3, Base: time.Millisecond, Multiplier: math.MaxFloat64, AttemptEstimate: time.Millisecond}
+	assert.ErrorIs(t, WithBackoff(func() error { return errors.New("failure") }, cfg).Run(ctx), ErrRetryAbandoned)
+}
+
+func TestWithBackoff_MustRetryUntilSucceeds(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	tsk := WithBackoff(func() error {
+		if attempts++; attempts < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, BackoffConfig{Attempts: 5, Base: time.Millisecond, Multiplier: 2})
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithBackoff_MustReturnLastError_WhenAttemptsRunOut(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	tsk := WithBackoff(func() error {
+		attempts++
+		return errors.New("never")
+	}, BackoffConfig{Attempts: 3, Base: time.Millisecond})
+
+	assert.ErrorContains(t, tsk.Run(context.Background()), "never")
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithBackoff_MustStopWaiting_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer time.AfterFunc(time.Millisecond*50, cancel).Stop()
+	attempts := int32(0)
+	tsk := WithBackoff(func() error {
+		atomic.AddInt32(&attempts, 1)
+		return errors.New("never")
+	}, BackoffConfig{Attempts: 3, Base: time.Hour})
+
+	start := time.Now()
+	assert.ErrorIs(t, tsk.Run(ctx), context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestWithBackoff_MustHandleNilStep_AsNoOp(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, WithBackoff(nil, BackoffConfig{}).Run(context.Background()))
+}
+
+func TestWithRetry_MustRetryUntilAttemptsRunOut(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	tsk := WithRetry(func() error {
+		attempts++
+		return errors.New("never")
+	}, 3, time.Millisecond)
+
+	assert.ErrorContains(t, tsk.Run(context.Background()), "never")
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, WithRetry(nil, 3, time.Millisecond).Run(context.Background()))
+}
+
+func TestWithRetryIf_MustReturnImmediately_WhenNotRetryable(t *testing.T) {
+	t.Parallel()
+	errPermanent := errors.New("permanent")
+	attempts := 0
+	tsk := WithRetryIf(func() error {
+		attempts++
+		return errPermanent
+	}, 5, time.Hour, func(err error) bool { return !errors.Is(err, errPermanent) })
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), errPermanent)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetryIf_MustExhaustAttempts_WhenRetryable(t *testing.T) {
+	t.Parallel()
+	errTransient := errors.New("transient")
+	attempts := 0
+	tsk := WithRetryIf(func() error {
+		attempts++
+		return errTransient
+	}, 4, time.Millisecond, func(err error) bool { return errors.Is(err, errTransient) })
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), errTransient)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestBackoffExponential_MustDoubleUntilCap(t *testing.T) {
+	t.Parallel()
+	backoff := BackoffExponential(time.Millisecond*100, time.Second, 0)
+	var delays []time.Duration
+	for attempt := 0; attempt < 6; attempt++ {
+		delays = append(delays, backoff.Delay(attempt))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Millisecond * 100, time.Millisecond * 200, time.Millisecond * 400,
+		time.Millisecond * 800, time.Second, time.Second,
+	}, delays)
+	assert.Equal(t, time.Hour*24, BackoffExponential(time.Hour*24, 0, 0).Delay(0))
+}
+
+func TestBackoffExponential_MustJitterBothWays(t *testing.T) {
+	t.Parallel()
+	backoff := BackoffExponential(time.Second, 0, 0.5)
+	below, above := false, false
+	for i := 0; i < 1000; i++ {
+		d := backoff.Delay(1)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, time.Second*3)
+		below, above = below || d < time.Second*2, above || d > time.Second*2
+	}
+	assert.True(t, below)
+	assert.True(t, above)
+}
+
+func TestWithRetryBackoff_MustWaitByInjectedBackoff(t *testing.T) {
+	t.Parallel()
+	var attempts []int
+	failure := errors.New("failure")
+	tsk := WithRetryBackoff(func() error { return failure }, 4, BackoffFunc(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return 0
+	}))
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, []int{0, 1, 2}, attempts)
+}
+
+func TestWithRetryBackoff_MustStopWaiting_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer time.AfterFunc(time.Millisecond*50, cancel).Stop()
+	tsk := WithRetryBackoff(func() error { return errors.New("failure") }, 3, BackoffExponential(time.Hour, 0, 0))
+
+	start := time.Now()
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.Canceled)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+	assert.NoError(t, WithRetryBackoff(func() error { return nil }, 3, nil).Run(context.Background()))
+}
+
+func TestWithRetryBudget_MustBoundAttemptsAcrossChain(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	calls := 0
+	flaky := WithRetry(func() error {
+		if calls++; calls < 3 {
+			return failure
+		}
+		return nil
+	}, 10, 0)
+	broken := WithRetry(func() error { return failure }, 10, 0)
+	r := NewRunner(WithRetryBudget(5, 0))
+
+	report, err := r.RunReport(context.Background(), flaky.Then(broken))
+	assert.ErrorIs(t, err, ErrRetryBudget)
+	assert.ErrorIs(t, err, failure)
+	assert.EqualError(t, err, "retry budget exhausted: failure")
+	assert.Equal(t, 3, report.Steps[0].Attempts)
+	assert.Equal(t, 2, report.Steps[1].Attempts)
+
+	calls = 0
+	report, err = r.RunReport(context.Background(), flaky)
+	assert.NoError(t, err, "budget must be fresh for every each run")
+	assert.Equal(t, 3, report.Steps[0].Attempts)
+}
+
+func TestWithRetryBudget_MustBoundTotalDelay(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	tsk := WithRetry(func() error {
+		attempts++
+		return errors.New("failure")
+	}, 10, time.Millisecond*10)
+
+	err := NewRunner(WithRetryBudget(0, time.Millisecond*25)).Run(context.Background(), tsk)
+	assert.ErrorIs(t, err, ErrRetryBudget)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_MustNotBeBounded_WithoutBudget(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	tsk := WithRetry(func() error {
+		attempts++
+		return errors.New("failure")
+	}, 10, 0)
+
+	report, err := tsk.RunReport(context.Background())
+	assert.NotErrorIs(t, err, ErrRetryBudget)
+	assert.Equal(t, 10, attempts)
+	assert.Equal(t, 10, report.Steps[0].Attempts)
+}
+
+func TestRetry_MustAbandon_WhenDeadlineTooClose(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	failure := errors.New("failure")
+	attempts := 0
+	tsk := WithRetry(func() error {
+		attempts++
+		return failure
+	}, 10, time.Millisecond*30)
+
+	start := time.Now()
+	err := RunSync(ctx, tsk)
+	assert.ErrorIs(t, err, ErrRetryAbandoned)
+	assert.ErrorIs(t, err, failure)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "retry abandoned: insufficient deadline: failure")
+	assert.Less(t, time.Since(start), time.Millisecond*100)
+	assert.InDelta(t, 3, attempts, 1)
+}
+
+func TestRetry_MustAbandon_WhenAttemptEstimateExceedsDeadline(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	attempts := 0
+	tsk := WithBackoff(func() error {
+		attempts++
+		return errors.New("failure")
+	}, BackoffConfig{Attempts: 10, Base: time.Millisecond, AttemptEstimate: time.Minute})
+
+	assert.ErrorIs(t, RunSync(ctx, tsk), ErrRetryAbandoned)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_MustNotRetry_WhenFatal(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	denied := errors.New("denied")
+	tsk := WithRetry(func() error {
+		attempts++
+		return fmt.Errorf("auth: %w", Fatal(denied))
+	}, 5, 0)
+
+	err := tsk.Run(context.Background())
+	assert.ErrorIs(t, err, denied)
+	assert.True(t, IsFatal(err))
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	tsk = WithRetryIf(func() error {
+		attempts++
+		return Fatal(denied)
+	}, 3, 0, func(error) bool { return true })
+	assert.ErrorIs(t, tsk.Run(context.Background()), denied)
+	assert.Equal(t, 3, attempts, "explicit retryable must take precedence")
+}
@@ -0,0 +1,76 @@
+package grace
+
+import "context"
+
+// RunOption configures a Runner.
+type RunOption func(r *Runner)
+
+// Runner runs chains as same as Task.Run does, with options applied to every each run.
+// A Runner can be shared by runs from multiple goroutines.
+type Runner struct {
+	stats     []*Stats
+	budget    *retryBudget
+	observers []Observer
+	hardKill  *hardKill
+	forceQuit *int // exit code on the second signal
+	reload    *reload
+}
+
+// NewRunner returns new Runner with given options applied.
+func NewRunner(opts ...RunOption) *Runner {
+	r := &Runner{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Run runs given Task as same as Task.Run does.
+func (r *Runner) Run(ctx context.Context, t Task) error {
+	_, err := r.RunReport(ctx, t)
+	return err
+}
+
+// RunReport runs given Task as same as Task.RunReport does.
+func (r *Runner) RunReport(ctx context.Context, t Task) (Report, error) {
+	rec := &recorder{observers: r.observers}
+	stop := r.hardKill.watch(t, rec)
+	err := run(r.context(ctx), t, rec, nil)
+	stop()
+	report := rec.report(t, err)
+	r.done(report)
+	return report, err
+}
+
+// Start runs given Task in background as same as Start does.
+func (r *Runner) Start(ctx context.Context, t Task) *Handle {
+	h := &Handle{done: make(chan struct{}), pause: &pauser{}, rec: &recorder{observers: r.observers}, steps: countSafe(t)}
+	go func() {
+		defer close(h.done)
+		stop := r.hardKill.watch(t, h.rec)
+		h.err = run(r.context(ctx), t, h.rec, h.pause)
+		stop()
+		h.report = h.rec.report(t, h.err)
+		h.status = status(h.err, h.current())
+		r.done(h.report)
+	}()
+	return h
+}
+
+// context returns the context for a run, which carries states of options for the run.
+func (r *Runner) context(ctx context.Context) context.Context {
+	if r.budget != nil {
+		ctx = context.WithValue(ctx, budgetKey{}, r.budget.fresh())
+	}
+	return ctx
+}
+
+// done hands the report of a run over to options.
+func (r *Runner) done(report Report) {
+	for _, s := range r.stats {
+		s.record(report)
+	}
+	notify(r.observers, func(o Observer) { o.OnRunEnd(report) })
+}
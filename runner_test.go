@@ -0,0 +1,22 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRunner_MustRunAsTaskDoes(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := With(nil).Then(With(func() error { return failure }))
+	r := NewRunner(nil)
+
+	assert.ErrorIs(t, r.Run(context.Background(), tsk), failure)
+	report, err := r.RunReport(context.Background(), tsk)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+	assert.ErrorIs(t, r.Start(context.Background(), tsk).Wait(), failure)
+	assert.NoError(t, r.Run(context.Background(), nil))
+}
@@ -0,0 +1,57 @@
+package grace
+
+import "context"
+
+// Semaphore bounds the number of steps in flight, across every each chain that shares it.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore returns new Semaphore of given size, which is at least one.
+func NewSemaphore(size int) *Semaphore {
+	if size < 1 {
+		size = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, size)}
+}
+
+// Acquire takes a slot from the Semaphore, waiting for one to be released if none is available.
+// It fails with the error of the context when the context is done before a slot is taken.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	if err := ctxErr(ctx); err != nil {
+		return err
+	}
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctxErr(ctx)
+	}
+}
+
+// Release returns a slot taken by Acquire. It panics when no slot is taken.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("grace: release of semaphore without acquire")
+	}
+}
+
+// WithSemaphore returns new Task that holds a slot of the Semaphore while given step runs.
+// When sem is nil, the step runs without any bound.
+func WithSemaphore(step Step, sem *Semaphore) Task {
+	if step == nil {
+		return With(nil)
+	}
+	if sem == nil {
+		return With(step)
+	}
+	return withCtx(func(ctx context.Context) error {
+		if err := sem.Acquire(ctx); err != nil {
+			return err
+		}
+		defer sem.Release()
+		return step()
+	})
+}
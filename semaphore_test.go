@@ -0,0 +1,76 @@
+package grace
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithSemaphore_MustBoundInFlight_AcrossChains(t *testing.T) {
+	t.Parallel()
+	sem := NewSemaphore(3)
+	inFlight, peak := &atomic.Int32{}, &atomic.Int32{}
+	step := func() error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 5)
+		return nil
+	}
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tsk := WithSemaphore(step, sem).Then(WithSemaphore(step, sem)).Then(WithSemaphore(step, sem))
+			assert.NoError(t, tsk.Run(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Equal(t, int32(3), peak.Load())
+}
+
+func TestSemaphore_Acquire_MustFail_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	sem := NewSemaphore(1)
+	assert.NoError(t, sem.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	assert.ErrorIs(t, sem.Acquire(ctx), context.DeadlineExceeded)
+
+	sem.Release()
+	assert.NoError(t, sem.Acquire(context.Background()))
+}
+
+func TestWithSemaphore_MustNotRunStep_WhenContextDoneOnAcquire(t *testing.T) {
+	t.Parallel()
+	sem := NewSemaphore(1)
+	assert.NoError(t, sem.Acquire(context.Background()))
+	defer sem.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	called := false
+	err := RunSync(ctx, WithSemaphore(func() error {
+		called = true
+		return nil
+	}, sem))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called)
+}
+
+func TestSemaphore_Release_MustPanic_WhenNotAcquired(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, NewSemaphore(1).Release)
+}
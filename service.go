@@ -0,0 +1,62 @@
+package grace
+
+import (
+	"context"
+	"errors"
+)
+
+// Service is a long-running component, modeled by a pair of tasks to start and stop it.
+type Service struct {
+	// Start runs the service, which is expected to block until the service ends, such as ListenAndServe.
+	Start Task
+	// Stop stops the service, making Start return, such as Shutdown of a server.
+	Stop Task
+}
+
+// Services returns new Task that starts every each of given services concurrently, then waits until any of Start
+// returns or the context is done. Then it runs every each Stop in reverse order, regardless of errors from others,
+// and waits for the rest of Start to return.
+// Stop runs with the context given to Run, but without its cancellation, so that services are stopped anyway.
+// The error that has triggered stopping, which is the one from Start returned first or of the context, is returned
+// joined with errors from Stop. Errors from the rest of Start are ignored, as they are stopped as requested.
+func Services(services ...Service) Task {
+	services = append([]Service(nil), services...) // let the caller reuse the slice
+	t := withCtx(func(parent context.Context) error {
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+		results := make(chan error, len(services))
+		started := 0
+		for _, s := range services {
+			if isNil(s.Start) {
+				continue
+			}
+			started++
+			go func(start Task) {
+				results <- start.Run(ctx)
+			}(s.Start)
+		}
+
+		var trigger error
+		select {
+		case trigger = <-results:
+			started--
+		case <-ctx.Done():
+			trigger = ctxErr(parent)
+		}
+
+		errs := []error{trigger}
+		stopCtx := context.WithoutCancel(parent)
+		for i := len(services) - 1; i >= 0; i-- {
+			if stop := services[i].Stop; !isNil(stop) {
+				errs = append(errs, stop.Run(stopCtx))
+			}
+		}
+		cancel()
+		for ; started > 0; started-- {
+			<-results
+		}
+		return errors.Join(errs...)
+	})
+	t.await = true // returns as soon as services are stopped
+	return t
+}
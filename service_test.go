@@ -0,0 +1,66 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeService is a Service which Start blocks until Stop is called.
+func fakeService(name string, order *[]string, mu *sync.Mutex) Service {
+	stop := make(chan struct{})
+	once := sync.Once{}
+	return Service{
+		Start: WithNoErr(func() { <-stop }),
+		Stop: WithNoErr(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			*order = append(*order, name)
+			once.Do(func() { close(stop) })
+		}),
+	}
+}
+
+func TestServices_MustStopInReverseOrder_WhenAnyStartReturns(t *testing.T) {
+	t.Parallel()
+	mu := &sync.Mutex{}
+	var order []string
+	failure := errors.New("failure")
+	failing := fakeService("failing", &order, mu)
+	failing.Start = With(func() error {
+		time.Sleep(time.Millisecond * 20)
+		return failure
+	})
+
+	tsk := Services(fakeService("db", &order, mu), fakeService("http", &order, mu), failing, Service{})
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, []string{"failing", "http", "db"}, order)
+}
+
+func TestServices_MustStop_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer time.AfterFunc(time.Millisecond*20, cancel).Stop()
+	mu := &sync.Mutex{}
+	var order []string
+	stopErr := errors.New("stop failed")
+	broken := fakeService("broken", &order, mu)
+	broken.Stop = broken.Stop.Then(With(func() error { return stopErr }))
+
+	err := Services(broken, fakeService("http", &order, mu)).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, stopErr)
+	assert.Equal(t, []string{"http", "broken"}, order, "must stop every each even on errors")
+}
+
+func TestServices_MustReturnNil_WhenStartReturnedNil(t *testing.T) {
+	t.Parallel()
+	mu := &sync.Mutex{}
+	var order []string
+	done := Service{Start: With(nil)}
+	assert.NoError(t, Services(fakeService("http", &order, mu), done).Run(context.Background()))
+	assert.Equal(t, []string{"http"}, order)
+}
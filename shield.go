@@ -0,0 +1,24 @@
+package grace
+
+import (
+	"context"
+	"time"
+)
+
+// Shield returns new Task that runs given chain to the end, even if the context given to Run is canceled.
+// The chain runs under a context without the cancellation of the parent, but with its own deadline of maxDuration,
+// so that it never runs forever. Values of the parent context are kept as is.
+// Shielded chain is run even if the context is already canceled when the chain reaches it, and Task.Run waits for it.
+// Use it for steps that must complete once started, such as releasing a lock or committing a log.
+func Shield(t Task, maxDuration time.Duration) Task {
+	if isNil(t) {
+		return With(nil)
+	}
+	node := withCtx(func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), maxDuration)
+		defer cancel()
+		return t.Run(ctx)
+	})
+	node.await, node.shielded = true, true
+	return node
+}
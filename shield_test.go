@@ -0,0 +1,102 @@
+package grace
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShield_MustComplete_WhenCanceledInProgress(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	started, completed := make(chan struct{}), &atomic.Bool{}
+	tsk := Shield(WithCtx(func(ctx context.Context) error {
+		close(started)
+		time.Sleep(time.Millisecond * 100)
+		if ctx.Err() == nil {
+			completed.Store(true)
+		}
+		return nil
+	}), time.Second)
+
+	go func() {
+		<-started
+		cancel()
+	}()
+	assert.NoError(t, tsk.Run(ctx))
+	assert.True(t, completed.Load())
+}
+
+func TestShield_MustFail_WhenMaxDurationExceeded(t *testing.T) {
+	t.Parallel()
+	tsk := Shield(WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), time.Millisecond*50)
+
+	start := time.Now()
+	assert.ErrorIs(t, tsk.Run(context.Background()), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+}
+
+func TestShield_MustRun_WhenContextAlreadyCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	completed, next := &atomic.Bool{}, &atomic.Bool{}
+	tsk := Shield(WithNoErr(func() {
+		time.Sleep(drainWait * 5)
+		completed.Store(true)
+	}), time.Second).Then(WithNoErr(func() { next.Store(true) }))
+
+	assert.ErrorIs(t, tsk.Run(ctx), context.Canceled)
+	assert.True(t, completed.Load(), "must wait for the shielded step")
+	assert.False(t, next.Load())
+}
+
+func TestShield_MustBeAwaited_WhenCanceledRightBefore(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	completed := &atomic.Bool{}
+	tsk := WithNoErr(cancel).Then(Shield(WithNoErr(func() {
+		time.Sleep(drainWait * 5)
+		completed.Store(true)
+	}), time.Second))
+
+	assert.NoError(t, tsk.Run(ctx), "must complete the chain")
+	assert.True(t, completed.Load(), "must wait for the shielded step")
+}
+
+func TestShield_MustRun_WhenContextAlreadyCanceled_Sync(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called, next := &atomic.Bool{}, &atomic.Bool{}
+	tsk := Shield(WithNoErr(func() { called.Store(true) }), time.Second).
+		Then(WithNoErr(func() { next.Store(true) }))
+
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.Canceled)
+	assert.True(t, called.Load())
+	assert.False(t, next.Load())
+}
+
+func TestShield_MustKeepContextValues(t *testing.T) {
+	t.Parallel()
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	var value any
+	tsk := Shield(WithCtx(func(ctx context.Context) error {
+		value = ctx.Value(key{})
+		return nil
+	}), time.Second)
+
+	assert.NoError(t, tsk.Run(ctx))
+	assert.Equal(t, "value", value)
+}
+
+func TestShield_MustReturnNoopTask_WhenNil(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, Shield(nil, time.Second).Run(context.Background()))
+}
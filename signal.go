@@ -0,0 +1,102 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrForceQuit is the cause of the context of a shutdown canceled by the second signal to RunOnSignal.
+var ErrForceQuit = errors.New("force quit")
+
+// WithForceQuit returns RunOption that makes the second signal to RunOnSignal terminate the process by os.Exit
+// with the code, rather than canceling the shutdown.
+func WithForceQuit(code int) RunOption {
+	return func(r *Runner) {
+		r.forceQuit = &code
+	}
+}
+
+// DefaultSignals returns the signals asking a process to terminate on the platform, which Main listens to by default:
+// os.Interrupt everywhere, along with syscall.SIGTERM where os/signal delivers it, such as on unix and windows.
+// On windows, os.Interrupt comes from Ctrl+C and Ctrl+Break, while syscall.SIGTERM comes from closing the console,
+// logging off and shutting down. Stop requests of windows services are not signals, thus not included.
+func DefaultSignals() []os.Signal {
+	return defaultSignals()
+}
+
+// RunOnSignal waits for the first signal from the channel, then runs given shutdown Task and returns its Report.
+// A signal received again during the shutdown forces it to quit: the context of the shutdown is canceled with
+// ErrForceQuit as the cause, or the process exits when WithForceQuit is given, after logging tasks completed thus far
+// through the logger of the context, as LoggerFrom returns.
+// The channel is read until the shutdown returns, so that operators are never left unheard; as signal.Notify never
+// blocks, it should be buffered. When the context is done before any signal, the shutdown never runs.
+//
+//	signals := make(chan os.Signal, 1)
+//	signal.Notify(signals, grace.DefaultSignals()...)
+//	report, err := grace.NewRunner().RunOnSignal(ctx, signals, shutdown)
+func (r *Runner) RunOnSignal(ctx context.Context, signals <-chan os.Signal, t Task) (Report, error) {
+	reloads := newReloader(ctx, r.reload)
+	for waiting := true; waiting; {
+		select {
+		case <-ctx.Done():
+			reloads.stop()
+			return Report{}, ctxErr(ctx)
+		case sig := <-signals:
+			if waiting = r.reload.matches(sig); waiting {
+				reloads.trigger()
+			}
+		}
+	}
+	return r.shutdown(r.stopReload(ctx, signals, reloads), signals, t)
+}
+
+// stopReload stops the reload in progress, if any, then returns the context for the shutdown. A signal received again
+// in the meantime forces to quit waiting for the reload, such as one stuck in a step awaited: the context is returned
+// canceled with ErrForceQuit as the cause, or the process exits when WithForceQuit is given.
+func (r *Runner) stopReload(ctx context.Context, signals <-chan os.Signal, reloads *reloader) context.Context {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		reloads.stop()
+	}()
+	for {
+		select {
+		case <-stopped:
+			return ctx
+		case sig := <-signals:
+			if r.reload.matches(sig) {
+				continue
+			}
+			LoggerFrom(ctx).Warn("grace: force quit while stopping reload", "signal", sig.String())
+			if r.forceQuit != nil {
+				exit(*r.forceQuit)
+			}
+			ctx, cancel := context.WithCancelCause(ctx)
+			cancel(ErrForceQuit)
+			return ctx
+		}
+	}
+}
+
+// shutdown runs given shutdown Task, which a signal from the channel forces to quit.
+func (r *Runner) shutdown(ctx context.Context, signals <-chan os.Signal, t Task) (Report, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	h := r.Start(ctx, t)
+	for {
+		select {
+		case <-h.Done():
+			return h.Report(), h.Wait()
+		case sig := <-signals:
+			if r.reload.matches(sig) {
+				continue
+			}
+			LoggerFrom(ctx).Warn("grace: force quit", "signal", sig.String(), "completed", h.rec.completed())
+			if r.forceQuit != nil {
+				exit(*r.forceQuit)
+			}
+			cancel(ErrForceQuit)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package grace
+
+import "os"
+
+// defaultSignals is os.Interrupt alone, the only signal defined everywhere.
+func defaultSignals() []os.Signal {
+	return []os.Signal{os.Interrupt}
+}
@@ -0,0 +1,99 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunner_RunOnSignal_MustRunShutdown_OnFirstSignal(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	ran := make(chan struct{})
+	go func() {
+		time.Sleep(time.Millisecond * 20)
+		signals <- os.Interrupt
+	}()
+
+	report, err := NewRunner().RunOnSignal(context.Background(), signals, Named("close", WithNoErr(func() { close(ran) })))
+	assert.NoError(t, err)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	<-ran
+}
+
+func TestRunner_RunOnSignal_MustCancelShutdown_OnSecondSignal(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+	buf := &bytes.Buffer{}
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(buf, nil)))
+	var cause error
+	tsk := Named("stop", With(nil)).Then(Named("drain", WithCtx(func(ctx context.Context) error {
+		signals <- os.Interrupt
+		<-ctx.Done()
+		cause = context.Cause(ctx)
+		return ctx.Err()
+	})))
+
+	report, err := NewRunner().RunOnSignal(ctx, signals, tsk)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, cause, ErrForceQuit)
+	assert.Equal(t, StepSucceeded, report.Steps[0].Status)
+	assert.Equal(t, StepFailed, report.Steps[1].Status)
+	assert.Contains(t, buf.String(), `msg="grace: force quit" signal=interrupt completed=[stop]`)
+}
+
+func TestRunner_RunOnSignal_MustExit_OnSecondSignal_WithForceQuit(t *testing.T) { // not parallel, as exit is replaced
+	codes := useExit(t)
+	signals := make(chan os.Signal, 2)
+	signals <- os.Interrupt
+	tsk := WithCtx(func(ctx context.Context) error {
+		signals <- os.Interrupt
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	_, err := NewRunner(WithForceQuit(130)).RunOnSignal(context.Background(), signals, tsk)
+	assert.Error(t, err)
+	assert.Equal(t, 130, <-codes)
+}
+
+func TestRunner_RunOnSignal_MustNotRun_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := NewRunner().RunOnSignal(ctx, nil, WithNoErr(func() { t.Fatal("must not run") }))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, report.Steps)
+}
+
+func TestDefaultSignals_MustIncludeInterrupt(t *testing.T) {
+	t.Parallel()
+	signals := DefaultSignals()
+	assert.Contains(t, signals, os.Interrupt)
+
+	signals[0] = nil
+	assert.Contains(t, DefaultSignals(), os.Interrupt, "must return a copy")
+}
+
+func TestMain_MustRunShutdown_OnEveryDefaultSignal(t *testing.T) {
+	t.Parallel()
+	for _, sig := range DefaultSignals() {
+		signals := make(chan os.Signal, 1)
+		signals <- sig
+		shutdown := &atomic.Bool{}
+		serve := WithCtx(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		cfg := &mainConfig{runner: &Runner{}}
+		assert.Equal(t, 0, cfg.main(serve, WithNoErr(func() { shutdown.Store(true) }), signals), sig.String())
+		assert.True(t, shutdown.Load(), sig.String())
+	}
+}
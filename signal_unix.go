@@ -0,0 +1,13 @@
+//go:build unix
+
+package grace
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultSignals are those asking a process to terminate, as sent by shells, init systems and orchestrators.
+func defaultSignals() []os.Signal {
+	return []os.Signal{os.Interrupt, syscall.SIGTERM}
+}
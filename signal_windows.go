@@ -0,0 +1,14 @@
+//go:build windows
+
+package grace
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultSignals are those of console events, as os/signal translates them: Ctrl+C and Ctrl+Break into os.Interrupt,
+// while closing the console, logging off and shutting down into syscall.SIGTERM.
+func defaultSignals() []os.Signal {
+	return []os.Signal{os.Interrupt, syscall.SIGTERM}
+}
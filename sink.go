@@ -0,0 +1,51 @@
+package grace
+
+import "sync"
+
+// Sink holds values collected by steps, in order of their execution.
+type Sink[T any] struct {
+	mu     sync.Mutex
+	values []T
+}
+
+// Collect returns new Sink, along with a function that wraps an operation producing a value into a Step, which runs
+// the operation on its execution, then stores the value into the Sink only when it returned no error.
+func Collect[T any]() (*Sink[T], func(fn func() (T, error)) Step) {
+	s := &Sink[T]{}
+	return s, func(fn func() (T, error)) Step {
+		return func() error {
+			v, err := fn()
+			if err != nil {
+				return err
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.values = append(s.values, v)
+			return nil
+		}
+	}
+}
+
+// Values returns a copy of values collected thus far.
+func (s *Sink[T]) Values() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([]T, len(s.values))
+	copy(values, s.values)
+	return values
+}
+
+// Adapt returns a Step that runs fn, then stores its result into out only when it returned no error.
+// It bridges functions producing values into a chain, capturing their results into variables.
+func Adapt[T any](fn func() (T, error), out *T) Step {
+	return func() error {
+		v, err := fn()
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			*out = v
+		}
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// valueOf returns an operation producing given value.
+func valueOf[T any](v T) func() (T, error) {
+	return func() (T, error) { return v, nil }
+}
+
+func TestCollect_MustStoreValuesOrderly(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[string]()
+	tsk := With(store(valueOf("a"))).Then(With(store(valueOf("b")))).Then(With(store(valueOf("c"))))
+
+	assert.Empty(t, sink.Values())
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c"}, sink.Values())
+}
+
+func TestCollect_MustNotStore_WhenNotReached(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	fail := With(func() error { return errors.New("failed") })
+	tsk := With(store(valueOf(1))).Then(fail).Then(With(store(valueOf(2))))
+
+	assert.Error(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1}, sink.Values())
+}
+
+func TestCollect_MustProduceValues_OnRun(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	calls, failure := 0, errors.New("failure")
+	next := func() (int, error) {
+		calls++
+		return calls * 10, nil
+	}
+	tsk := With(store(next)).Then(With(store(next)))
+	assert.Zero(t, calls, "must not produce until run")
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{10, 20, 30, 40}, sink.Values())
+
+	err := With(store(func() (int, error) { return 50, failure })).Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []int{10, 20, 30, 40}, sink.Values(), "must not store on error")
+}
+
+func TestSink_Values_MustReturnCopy(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	assert.NoError(t, With(store(valueOf(1))).Run(context.Background()))
+
+	values := sink.Values()
+	values[0] = 42
+	assert.Equal(t, []int{1}, sink.Values())
+}
+
+func TestAdapt_MustStoreResult_WhenSucceeded(t *testing.T) {
+	t.Parallel()
+	var port int
+	var addr string
+	tsk := With(Adapt(func() (int, error) { return 8080, nil }, &port)).
+		Then(With(Adapt(func() (string, error) { return "localhost", nil }, &addr))).
+		Then(With(Adapt(func() (int, error) { return 0, nil }, nil)))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 8080, port)
+	assert.Equal(t, "localhost", addr)
+}
+
+func TestAdapt_MustLeaveOutput_WhenFailed(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	port := 80
+	step := Adapt(func() (int, error) { return 8080, failure }, &port)
+
+	assert.ErrorIs(t, With(step).Run(context.Background()), failure)
+	assert.Equal(t, 80, port)
+}
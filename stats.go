@@ -0,0 +1,97 @@
+package grace
+
+import (
+	"sync"
+	"time"
+)
+
+// WithStats returns RunOption that accumulates timing of every each task into given Stats.
+func WithStats(s *Stats) RunOption {
+	return func(r *Runner) {
+		if s != nil {
+			r.stats = append(r.stats, s)
+		}
+	}
+}
+
+// Stats accumulates timing of tasks across runs of a Runner, identifying tasks by their names,
+// or their indices such as "#1" if not named. Tasks of the same name are accumulated together.
+// Zero value of Stats is ready to use, and it is safe for concurrent use.
+type Stats struct {
+	mu    sync.Mutex
+	runs  int
+	steps []StepStats
+	index map[string]int
+}
+
+// StatsSnapshot is a copy of Stats at a moment.
+type StatsSnapshot struct {
+	// Runs is the number of runs accumulated.
+	Runs int
+	// Steps in order of their first appearance.
+	Steps []StepStats
+}
+
+// StepStats is accumulated timing of a task.
+type StepStats struct {
+	// Name of the task, or its index such as "#1" if not named.
+	Name string
+	// Count is the number of times the task has run, including failures.
+	Count int
+	// Errors is the number of times the task has failed or panicked.
+	Errors int
+	// Min is the shortest duration of the task.
+	Min time.Duration
+	// Max is the longest duration of the task.
+	Max time.Duration
+	// Mean is the average duration of the task.
+	Mean time.Duration
+	// Total is the sum of durations of the task.
+	Total time.Duration
+}
+
+// Snapshot returns a copy of accumulated timing.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	steps := make([]StepStats, len(s.steps))
+	copy(steps, s.steps)
+	return StatsSnapshot{Runs: s.runs, Steps: steps}
+}
+
+// Reset discards everything accumulated.
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs, s.steps, s.index = 0, nil, nil
+}
+
+// record accumulates tasks which have run in given Report.
+func (s *Stats) record(report Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+	s.runs++
+	for _, step := range report.Steps {
+		if step.Start.IsZero() { // never run
+			continue
+		}
+		name := step.label()
+		i, ok := s.index[name]
+		if !ok {
+			i = len(s.steps)
+			s.index[name] = i
+			s.steps = append(s.steps, StepStats{Name: name, Min: step.Duration})
+		}
+		st := &s.steps[i]
+		st.Count++
+		if step.Status == StepFailed || step.Status == StepPanicked {
+			st.Errors++
+		}
+		st.Min, st.Max = min(st.Min, step.Duration), max(st.Max, step.Duration)
+		st.Total += step.Duration
+		st.Mean = st.Total / time.Duration(st.Count)
+	}
+}
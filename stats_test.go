@@ -0,0 +1,73 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStats_MustAccumulateAcrossRuns(t *testing.T) {
+	t.Parallel()
+	stats := &Stats{}
+	r := NewRunner(WithStats(stats))
+	sleep, fail := time.Millisecond*10, false
+	tsk := Named("sleep", WithNoErr(func() { time.Sleep(sleep) })).
+		Then(With(func() error {
+			if fail {
+				return errors.New("failure")
+			}
+			return nil
+		})).
+		Then(Named("last", With(nil)))
+
+	assert.NoError(t, r.Run(context.Background(), tsk))
+	sleep, fail = time.Millisecond*30, true
+	assert.Error(t, r.Run(context.Background(), tsk))
+
+	snapshot := stats.Snapshot()
+	assert.Equal(t, 2, snapshot.Runs)
+	assert.Len(t, snapshot.Steps, 3)
+
+	s := snapshot.Steps[0]
+	assert.Equal(t, "sleep", s.Name)
+	assert.Equal(t, 2, s.Count)
+	assert.Zero(t, s.Errors)
+	assert.GreaterOrEqual(t, s.Min, time.Millisecond*10)
+	assert.Less(t, s.Min, time.Millisecond*30)
+	assert.GreaterOrEqual(t, s.Max, time.Millisecond*30)
+	assert.Equal(t, s.Total/2, s.Mean)
+
+	assert.Equal(t, "#1", snapshot.Steps[1].Name)
+	assert.Equal(t, 2, snapshot.Steps[1].Count)
+	assert.Equal(t, 1, snapshot.Steps[1].Errors)
+	assert.Equal(t, 1, snapshot.Steps[2].Count, "must not count tasks never run")
+
+	stats.Reset()
+	assert.Equal(t, StatsSnapshot{Steps: []StepStats{}}, stats.Snapshot())
+	assert.Len(t, snapshot.Steps, 3, "snapshot must be a copy")
+}
+
+func TestStats_MustBeSafe_ForConcurrentRuns(t *testing.T) {
+	t.Parallel()
+	stats := &Stats{}
+	r := NewRunner(WithStats(stats))
+	tsk := Named("a", With(nil)).Then(Named("b", With(nil)))
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, r.Run(context.Background(), tsk))
+			stats.Snapshot()
+		}()
+	}
+	wg.Wait()
+	snapshot := stats.Snapshot()
+	assert.Equal(t, 50, snapshot.Runs)
+	assert.Equal(t, 50, snapshot.Steps[0].Count)
+	assert.Equal(t, 50, snapshot.Steps[1].Count)
+}
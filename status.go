@@ -0,0 +1,72 @@
+package grace
+
+import "fmt"
+
+// State is a lifecycle state of a run.
+type State int
+
+const (
+	// StatePending is a state of a run which has not started any task yet.
+	StatePending State = iota
+	// StateRunning is a state of a run which is running a task.
+	StateRunning
+	// StateSucceeded is a state of a run which has returned no error.
+	StateSucceeded
+	// StateFailed is a state of a run which has returned an error.
+	StateFailed
+	// StateCanceled is a state of a run which has returned as its context is canceled or its deadline exceeded.
+	StateCanceled
+)
+
+var stateNames = [...]string{"pending", "running", "succeeded", "failed", "canceled"}
+
+// String implements fmt.Stringer
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return "unknown"
+	}
+	return stateNames[s]
+}
+
+// Status describes a run at a moment.
+type Status struct {
+	// State of the run.
+	State State
+	// Index of the task started last, starting from zero. Meaningless while pending.
+	Index int
+	// Name of the task started last, empty if not named.
+	Name string
+	// Steps is the number of tasks in the chain.
+	Steps int
+	// Err returned from the run, when failed or canceled.
+	Err error
+}
+
+// String implements fmt.Stringer, such as "running: draining connections (step 2/5)".
+func (s Status) String() string {
+	switch s.State {
+	case StateRunning:
+		if s.Name == "" {
+			return fmt.Sprintf("%v: step %d/%d", s.State, s.Index+1, s.Steps)
+		}
+		return fmt.Sprintf("%v: %s (step %d/%d)", s.State, s.Name, s.Index+1, s.Steps)
+	case StateFailed, StateCanceled:
+		return fmt.Sprintf("%v: %v", s.State, s.Err)
+	default:
+		return s.State.String()
+	}
+}
+
+// status returns Status of a run which has returned given error, with the task started last.
+func status(err error, last Status) Status {
+	switch {
+	case err == nil:
+		last.State = StateSucceeded
+	case isContextErr(err):
+		last.State = StateCanceled
+	default:
+		last.State = StateFailed
+	}
+	last.Err = err
+	return last
+}
@@ -0,0 +1,98 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatus_String_MustDescribeState(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "pending", Status{Steps: 5}.String())
+	assert.Equal(t, "running: draining connections (step 2/5)",
+		Status{State: StateRunning, Index: 1, Name: "draining connections", Steps: 5}.String())
+	assert.Equal(t, "running: step 3/5", Status{State: StateRunning, Index: 2, Steps: 5}.String())
+	assert.Equal(t, "succeeded", Status{State: StateSucceeded}.String())
+	assert.Equal(t, "failed: failure", Status{State: StateFailed, Err: errors.New("failure")}.String())
+	assert.Equal(t, "canceled: context canceled", Status{State: StateCanceled, Err: context.Canceled}.String())
+	assert.Equal(t, "unknown", State(-1).String())
+}
+
+func TestHandle_Status_MustReportCurrentStep(t *testing.T) {
+	t.Parallel()
+	started, release := make(chan struct{}), make(chan struct{})
+	tsk := Named("stop accepting", With(nil)).
+		Then(Named("draining connections", WithNoErr(func() {
+			close(started)
+			<-release
+		}))).
+		Then(With(nil))
+
+	h := Start(context.Background(), tsk)
+	<-started
+	status := h.Status()
+	assert.Equal(t, StateRunning, status.State)
+	assert.Equal(t, 1, status.Index)
+	assert.Equal(t, "draining connections", status.Name)
+	assert.Equal(t, 3, status.Steps)
+	assert.Equal(t, "running: draining connections (step 2/3)", status.String())
+
+	close(release)
+	assert.NoError(t, h.Wait())
+	assert.Equal(t, StateSucceeded, h.Status().State)
+	assert.Equal(t, h.Status(), h.Status(), "must be stable after completion")
+}
+
+func TestHandle_Status_MustBePending_BeforeFirstStep(t *testing.T) {
+	t.Parallel()
+	h := &Handle{done: make(chan struct{}), rec: &recorder{}, steps: 2}
+	assert.Equal(t, Status{State: StatePending, Steps: 2}, h.Status())
+}
+
+func TestHandle_Status_MustBeConsistentWithError(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	h := Start(context.Background(), With(nil).Then(With(func() error { return failure })))
+	err := h.Wait()
+	status := h.Status()
+	assert.Equal(t, StateFailed, status.State)
+	assert.Equal(t, err, status.Err)
+	assert.Equal(t, 1, status.Index)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	h = Start(ctx, WithCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	err = h.Wait()
+	assert.Equal(t, StateCanceled, h.Status().State)
+	assert.Equal(t, err, h.Status().Err)
+}
+
+func TestHandle_Status_MustBeSafe_FromOtherGoroutines(t *testing.T) {
+	t.Parallel()
+	b := &Builder{}
+	for i := 0; i < 100; i++ {
+		b.Append(WithNoErr(func() {}))
+	}
+	h := Start(context.Background(), b.Task())
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if h.Status().State == StateSucceeded {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, h.Wait())
+	assert.Equal(t, 99, h.Status().Index)
+}
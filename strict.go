@@ -0,0 +1,20 @@
+package grace
+
+import "context"
+
+// RunStrict runs given Task on the calling goroutine as same as RunSync does, but never recovers panics of its steps
+// into PanicError, so that they propagate to the caller, such as for crash-only designs restarted by a supervisor.
+// Panics of steps run on other goroutines, such as by All or StepTimeout, crash the process instead.
+// Panics recovered explicitly, such as by Recover, are left as they are.
+func RunStrict(ctx context.Context, t Task) error {
+	return RunSync(context.WithValue(ctx, strictKey{}, true), t)
+}
+
+// strictKey is the context key which tells panics of steps are not to be recovered. See RunStrict.
+type strictKey struct{}
+
+// isStrict tells whether panics of steps run with the context are not to be recovered.
+func isStrict(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictKey{}).(bool)
+	return strict
+}
@@ -0,0 +1,48 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRunStrict_MustPanic_WhileRunConverts(t *testing.T) {
+	t.Parallel()
+	ran := false
+	tsk := WithNoErr(func() { panic("boom") }).Then(WithNoErr(func() { ran = true }))
+
+	var pe *PanicError
+	assert.True(t, errors.As(tsk.Run(context.Background()), &pe))
+	assert.PanicsWithValue(t, "boom", func() { _ = RunStrict(context.Background(), tsk) })
+	assert.False(t, ran)
+}
+
+func TestRunStrict_MustRunCleanups_WhenPanicking(t *testing.T) {
+	t.Parallel()
+	cleaned := false
+	tsk := WithCtx(func(ctx context.Context) error {
+		OnCleanup(ctx, func() { cleaned = true })
+		panic("boom")
+	})
+
+	assert.Panics(t, func() { _ = RunStrict(context.Background(), tsk) })
+	assert.True(t, cleaned)
+}
+
+func TestRunStrict_MustLeaveRecover(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := Recover(func() error { panic("boom") }, func(any) error { return failure })
+
+	assert.ErrorIs(t, RunStrict(context.Background(), tsk), failure)
+}
+
+func TestRunStrict_MustRunAsRunSync(t *testing.T) {
+	t.Parallel()
+	count := 0
+	tsk := WithNoErr(func() { count++ }).Then(WithNoErr(func() { count++ }))
+
+	assert.NoError(t, RunStrict(context.Background(), tsk))
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,59 @@
+package grace
+
+import (
+	"maps"
+	"sort"
+	"strings"
+)
+
+// WithTags returns a copy of given Task, which its first task carries given tags, such as its component and owner,
+// merged over those it carries already. Tags are given to observers by StepInfo and recorded in Report, and errors
+// from the task are identified by StepError which tells them. Given map is copied, hence never affects the Task.
+func WithTags(t Task, tags map[string]string) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	tt, ok := t.(*task)
+	if !ok {
+		tt = withCtx(t.Run)
+	} else {
+		tt = tt.clone()
+	}
+	if len(tags) > 0 {
+		merged := make(map[string]string, len(tt.tags)+len(tags))
+		maps.Copy(merged, tt.tags)
+		maps.Copy(merged, tags)
+		tt.tags = merged
+	}
+	return tt
+}
+
+// Tags implements Task.Tags
+func (t *task) Tags() map[string]string {
+	return maps.Clone(t.tags)
+}
+
+// tagsOf returns the tags of given Task if any, which must not be modified.
+func tagsOf(t Task) map[string]string {
+	if tt, ok := t.(*task); ok && tt != nil {
+		return tt.tags
+	}
+	return nil
+}
+
+// formatTags formats tags sorted by keys, such as "[component=db owner=storage]". Empty tags format an empty string.
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + tags[k]
+	}
+	return "[" + strings.Join(pairs, " ") + "]"
+}
@@ -0,0 +1,65 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithTags_MustRoundTrip(t *testing.T) {
+	t.Parallel()
+	tags := map[string]string{"component": "db", "owner": "storage"}
+	tsk := WithTags(With(nil), tags)
+	tags["owner"] = "changed"
+
+	assert.Equal(t, map[string]string{"component": "db", "owner": "storage"}, tsk.Tags())
+	tsk.Tags()["owner"] = "changed"
+	assert.Equal(t, "storage", tsk.Tags()["owner"], "must return a copy")
+	assert.Nil(t, With(nil).Tags())
+}
+
+func TestWithTags_MustMerge_WithoutAffectingGivenTask(t *testing.T) {
+	t.Parallel()
+	base := WithTags(With(nil), map[string]string{"component": "db", "owner": "storage"})
+	tsk := WithTags(base, map[string]string{"owner": "platform"})
+
+	assert.Equal(t, map[string]string{"component": "db", "owner": "platform"}, tsk.Tags())
+	assert.Equal(t, "storage", base.Tags()["owner"])
+	assert.Equal(t, tsk.Tags(), Named("named", tsk).Then(With(nil)).Tags())
+}
+
+// tagged is an Observer which records tags of tasks started.
+type tagged struct {
+	events
+	tags *[]map[string]string
+}
+
+func (o tagged) OnStepStart(info StepInfo) {
+	*o.tags = append(*o.tags, info.Tags)
+}
+
+func TestWithTags_MustBeObserved(t *testing.T) {
+	t.Parallel()
+	var out []string
+	var tags []map[string]string
+	tsk := WithTags(With(nil), map[string]string{"component": "db"}).Then(With(nil))
+
+	report, err := NewRunner(WithObserver(tagged{events{"", &out}, &tags})).RunReport(context.Background(), tsk)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"component": "db"}, nil}, tags)
+	assert.Equal(t, map[string]string{"component": "db"}, report.Steps[0].Tags)
+}
+
+func TestWithTags_MustIdentifyError(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	tsk := With(nil).Then(WithTags(With(func() error { return failure }), map[string]string{"owner": "storage", "component": "db"}))
+
+	err := tsk.Run(context.Background())
+	var stepErr *StepError
+	assert.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, "step 1 [component=db owner=storage]: failure", err.Error())
+	assert.Equal(t, "task 'close' (step 0) [owner=storage]: failure",
+		Named("close", WithTags(With(func() error { return failure }), map[string]string{"owner": "storage"})).Run(context.Background()).Error())
+}
@@ -4,31 +4,158 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Task is an abstraction that represents a single task.
 // This may, or may not have chained tasks
+//
+// Tasks from this package hold no mutable state of a run, thus a Task can be run concurrently
+// from multiple goroutines, as long as its steps are safe to do so.
+//
+// Panics during a run are converted into PanicError, including those from steps, middlewares, and Next or Step of
+// tasks implemented outside of this package. Panics while building a chain, such as from Then, are not recovered.
 type Task interface {
 	// Run with context. This context will be propagated to every chained task.
 	Run(ctx context.Context) error
 
 	// Then chains this Task with that Task; every each as a new, copied Task instance.
 	// The action is immutable, hence does not affect caller.
+	// The chain is copied lazily, once on its first traversal, hence building a chain of n tasks by Then
+	// costs O(n) in total.
 	Then(that Task) Task
 
+	// ThenAll chains this Task with given tasks in order, as same as calling Then for every each of them,
+	// yet copies every each chain only once. Nil tasks are ignored, and no tasks returns this Task as is.
+	ThenAll(tasks ...Task) Task
+
 	// Step returns a grace.Step instance that is assigned to this Task instance.
 	Step() Step
 
 	// Next returns a grace.Task instance that is assigned as next task from this Task.
 	Next() Task
+
+	// RunReport runs as same as Run does, but also returns a Report describing how every each task went.
+	RunReport(ctx context.Context) (Report, error)
+
+	// RunObserved runs as same as Run does, but also notifies given StepObserver of every each task started and ended.
+	// A task abandoned on context done is notified of as ended with the error of the context, as Run returns.
+	RunObserved(ctx context.Context, observer StepObserver) error
+
+	// Fallback returns a new Task that runs this Task, then runs that Task only when this Task failed with an error.
+	// Context cancellation or deadline is not considered as a failure, hence never triggers that Task.
+	Fallback(that Task) Task
+
+	// OnSuccess returns a new Task that runs this Task, then calls f once when the whole chain returned no error.
+	OnSuccess(f func()) Task
+
+	// OnFailure returns a new Task that runs this Task, then calls f once with the error when the whole chain failed,
+	// including panics recovered and context cancellation or deadline.
+	// Panics from callbacks are not swallowed, but returned from the run as PanicError.
+	OnFailure(f func(err error)) Task
+
+	// MapError returns a new Task that runs this Task, then returns the error of the whole chain transformed by fn,
+	// including panics recovered and context cancellation or deadline; see ExemptContextErr to leave the latter.
+	// Nil error is never given to fn, and fn returning nil makes the run succeed. Nil fn returns this Task as is.
+	MapError(fn func(err error) error) Task
+
+	// Compact returns an equivalent chain without no-op tasks, which are those created by With(nil) and not named.
+	// Tasks that may have side effects are never removed. When every each task is removed, a no-op Task is returned.
+	Compact() Task
+
+	// Reverse returns new chain of the tasks in reverse order, such as a teardown chain of an acquisition chain.
+	Reverse() Task
+
+	// Dedup returns an equivalent chain without consecutive duplicates of the same task instance, such as t.Then(t)
+	// by a composition mistake, so that the task runs only once there. Copies of a task by this package, such as those
+	// by Then, count as the same instance, unless named or tagged differently, such as by Named.
+	// Tasks implemented by others are never removed.
+	Dedup() Task
+
+	// Tags returns a copy of the tags carried by this Task, as given by WithTags. Nil if none.
+	Tags() map[string]string
 }
 
-// With returns new Task instance
+// With returns new Task instance, which the step is wrapped by middlewares registered with Use.
 func With(step Step) Task {
+	stack := registered()
 	if step == nil {
-		step = func() error { return nil }
+		// no longer a no-op once wrapped, as middlewares may have side effects
+		return &task{step: wrapStep(stack, func() error { return nil }), noop: len(stack) == 0}
+	}
+	return &task{step: wrapStep(stack, step)}
+}
+
+// Named returns a copy of given Task, which its first task is identified by the name.
+// When given Task is not created by this package, the whole chain is wrapped as a single task with the name.
+func Named(name string, t Task) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	tt, ok := t.(*task)
+	if !ok {
+		tt = withCtx(t.Run)
+	} else {
+		tt = tt.clone()
+	}
+	tt.name = name
+	return tt
+}
+
+// WithCtx returns new Task of the step that receives the context given to Run.
+func WithCtx(step StepCtx) Task {
+	if step == nil {
+		return With(nil)
+	}
+	return withCtx(step)
+}
+
+// Intercept returns a copy of given chain, where every each step is replaced by the one returned from wrap.
+// Wrap receives the information of the task with its step, and tasks keep their names.
+// Use it to add cross-cutting concerns to an existing chain, such as tracing.
+func Intercept(t Task, wrap func(info StepInfo, step StepCtx) StepCtx) Task {
+	b := &Builder{}
+	for index := 0; !isNil(t); index, t = index+1, t.Next() {
+		node, ok := t.(*task)
+		if ok {
+			node = node.clone()
+		} else {
+			node = &task{step: t.Step()}
+		}
+		step, plain := node.run, node.step
+		if step == nil {
+			step = func(context.Context) error { return plain() }
+		}
+		if wrapped := wrap(StepInfo{Index: index, Name: node.name, Tags: node.tags}, step); wrapped != nil {
+			node.step = func() error { return wrapped(context.Background()) }
+			node.run, node.noop = wrapped, false
+		}
+		node.next, node.pending = nil, nil
+		b.nodes = append(b.nodes, node)
 	}
-	return &task{step, nil}
+	return b.Task()
+}
+
+// RequiresAtLeast returns a copy of given Task, which is skipped when the context given to Run has less time
+// remaining than d until its deadline, rather than being started to be killed halfway.
+// The skipped task is reported with ErrSkippedDeadline, and the chain continues with the next task.
+// When given Task has chained tasks, the whole chain is wrapped as a single task to be skipped.
+func RequiresAtLeast(d time.Duration, t Task) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	tt, ok := t.(*task)
+	if !ok || !isNil(tt.Next()) {
+		tt = withCtx(t.Run)
+	} else {
+		tt = tt.clone()
+	}
+	tt.requires = d
+	return tt
 }
 
 // WithNoErr returns new Task that always returns nil
@@ -42,83 +169,466 @@ func WithNoErr(step func()) Task {
 	})
 }
 
+// Recover returns new Task that converts panic from given step into an error by onPanic,
+// rather than the default conversion. When onPanic returns nil, the panic is swallowed and the chain continues.
+func Recover(step Step, onPanic func(any) error) Task {
+	if step == nil {
+		return With(nil)
+	}
+	if onPanic == nil {
+		onPanic = panicErr
+	}
+	return With(func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = onPanic(p)
+			}
+		}()
+		return step()
+	})
+}
+
+// WithValidate returns new Task that runs validate first, then runs the step only when validate returns nil.
+// Otherwise, the Task fails with the error from validate, including the one converted from panic.
+func WithValidate(validate func() error, step Step) Task {
+	if validate == nil {
+		return With(step)
+	}
+	if step == nil {
+		step = func() error { return nil }
+	}
+	return With(func() error {
+		if err := guard(validate); err != nil {
+			return err
+		}
+		return step()
+	})
+}
+
+// guard runs given function, converting panic into an error if any.
+func guard(fn func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicErr(p)
+		}
+	}()
+	return fn()
+}
+
 type Step func() error
 
+// StepCtx is a step that receives the context given to Run, thus can respond to its cancellation.
+type StepCtx func(ctx context.Context) error
+
+// StepInfo identifies a task in the chain.
+type StepInfo struct {
+	// Index of the task in the chain, starting from zero.
+	Index int
+	// Name of the task, empty if not named.
+	Name string
+	// Tags of the task, as given by WithTags. Nil if none; must not be modified.
+	Tags map[string]string
+}
+
+// ErrStopChain stops the chain successfully when returned from a step; the rest of the chain is skipped and
+// Run returns nil. Use it when a step finds the rest unnecessary, as it is never considered as a failure.
+var ErrStopChain = errors.New("stop chain")
+
+// ErrSkippedDeadline is reported for a task skipped as the deadline of the context is too close to run it.
+// See RequiresAtLeast.
+var ErrSkippedDeadline = errors.New("skipped as deadline is too close")
+
 // task as an implementation of Task
 type task struct {
 	step Step
 	next Task
+	name string
+
+	// run is a context aware variant of step. When present, it takes precedence over step.
+	run StepCtx
+
+	// await tells Run to wait for the step even when the context is done, as the step returns promptly on it.
+	await bool
+
+	// shielded tells the step runs regardless of the context being done.
+	shielded bool
+
+	// requires is the minimum time to be remaining until the deadline of the context, for the step to be run.
+	requires time.Duration
+
+	// noop tells the step does nothing, thus can be removed by Compact.
+	noop bool
+
+	// identify tells timeouts of the step by ErrStepTimeout are identified by StepError, even if the task is not named.
+	identify bool
+
+	// invalidate discards the result cached by Memo.
+	invalidate func()
+
+	// tags are metadata of the step given by WithTags, which is never modified but replaced.
+	tags map[string]string
+
+	// origin is the task this one has been copied from, which identifies the same task instance across copies.
+	// Nil if not a copy.
+	origin *task
+
+	// pending is tasks appended by Then, not yet copied into the chain after next.
+	// It must be cleared whenever next is replaced.
+	pending *pending
+}
+
+// pending is tasks appended after a chain, linked from the last one appended.
+// Tasks sharing it share the chain resolved, as they share next as well.
+type pending struct {
+	last Task
+	prev *pending
+
+	once sync.Once
+	next Task
+}
+
+// resolve returns given next chain followed by the pending tasks in order, which is copied only once.
+func (p *pending) resolve(next Task) Task {
+	p.once.Do(func() {
+		// chain from the last one; every each Then of this package costs O(1), as it copies nothing but the head.
+		tail := p.last
+		for q := p.prev; q != nil; q = q.prev {
+			tail = q.last.Then(tail)
+		}
+		if isNil(next) {
+			p.next = tail
+		} else {
+			p.next = next.Then(tail)
+		}
+	})
+	return p.next
+}
+
+// withCtx returns new task that receives context on its execution, which is wrapped by middlewares registered with Use.
+func withCtx(run StepCtx) *task {
+	run = applyMiddlewaresCtx(run)
+	return &task{
+		step: func() error { return run(context.Background()) },
+		run:  run,
+	}
+}
+
+// clone returns a shallow copy of the task, which originates from the same task instance.
+func (t *task) clone() *task {
+	cp := *t
+	cp.origin = t.identity()
+	return &cp
+}
+
+// identity returns the task instance which this task originates from.
+func (t *task) identity() *task {
+	if t.origin != nil {
+		return t.origin
+	}
+	return t
+}
+
+// duplicates tells whether this task is a copy of the same task instance as given one, named and tagged the same,
+// thus runs and reports the same.
+func (t *task) duplicates(that *task) bool {
+	return t.identity() == that.identity() && t.name == that.name && maps.Equal(t.tags, that.tags)
+}
+
+// exec executes the step of given Task with context, if the Task is aware of it.
+func exec(ctx context.Context, t Task) error {
+	if tt, ok := t.(*task); ok && tt.run != nil {
+		return tt.run(ctx)
+	}
+	return t.Step()()
 }
 
 // Run implement Task.Run
 func (t *task) Run(ctx context.Context) error {
-	errChan, done := make(chan error, 1), make(chan struct{}, 1)
+	return run(ctx, t, nil, nil)
+}
+
+// RunReport implements Task.RunReport
+func (t *task) RunReport(ctx context.Context) (Report, error) {
+	rec := &recorder{}
+	err := run(ctx, t, rec, nil)
+	return rec.report(t, err), err
+}
+
+// RunObserved implements Task.RunObserved
+func (t *task) RunObserved(ctx context.Context, observer StepObserver) error {
+	if observer == nil {
+		return t.Run(ctx)
+	}
+	return run(ctx, t, &recorder{observers: []Observer{&stepObserver{observer: observer}}}, nil)
+}
+
+// drainWait is how long Run waits for the step in progress to report its error once the context is done,
+// so that an error observed at nearly the same moment is not masked by the context error.
+const drainWait = time.Millisecond * 10
+
+// run walks the chain in a separate goroutine, and returns shortly after the context is done,
+// unless the step in progress is the one to be awaited. A chain completed in the meantime returns nil.
+// Only a step in progress is ever abandoned, thus a shielded step reached after the context is done is awaited.
+func run(ctx context.Context, t Task, rec *recorder, pause *pauser) error {
+	errChan, state := make(chan error, 1), &walkState{}
 	go func() {
-		// handle panic if any, then deferring close for channels
-		defer func() {
-			if p := recover(); p != nil { // check panic content
-				// check if panic content is either an error or a string
-				if err, ok := p.(error); ok { // error
-					errChan <- err
-				} else if str, isStr := p.(string); isStr { // string
-					errChan <- errors.New(str)
-				} else { // not nil, not error, not string
-					errChan <- fmt.Errorf("%+v", p)
+		errChan <- walk(ctx, t, rec, state, pause)
+	}()
+
+	select {
+	case <-ctx.Done(): // context done will always be faster if done ever happens
+		ticker := time.NewTicker(drainWait)
+		defer ticker.Stop()
+		for {
+			if state.awaited() { // step reports its own result on context done, such as partial results
+				return withCtxErr(ctx, <-errChan)
+			}
+			select {
+			case err := <-errChan:
+				return withCtxErr(ctx, err)
+			case <-ticker.C:
+				if state.abandon() { // abandon the step in progress
+					err := ctxErr(ctx)
+					rec.abandon(err)
+					return err
 				}
+				// between steps, which soon either finishes the walk or enters a step, such as a shielded one
 			}
-			defer close(errChan)
-			defer close(done)
-		}()
+		}
+	case err := <-errChan: // propagate error, or nil as we observed no error thus far
+		return withCtxErr(ctx, err)
+	}
+}
 
-		// assign initial step
-		var tt Task
-		tt = t
+// walkState tells what the walk of run is doing, so that run never abandons the walk but in a step.
+// Nil walkState tracks nothing.
+type walkState struct {
+	v atomic.Int32
+}
 
-	dig:
-		if ctx.Err() != nil { // context canceled or deadline exceeded, etc
-			return
-		}
-		step := tt.Step()
+const (
+	walkBetween   int32 = iota // between steps, which soon either finishes or enters a step
+	walkStep                   // in a step, which can be abandoned
+	walkAwait                  // in a step to be awaited
+	walkAbandoned              // in a step abandoned by run, after which the walk must go no further
+)
 
-		if err := step(); err != nil {
-			errChan <- err
+// enter tells the walk has entered given Task.
+func (s *walkState) enter(t Task) {
+	if s == nil {
+		return
+	}
+	if node, ok := t.(*task); ok && node.await {
+		s.v.Store(walkAwait)
+		return
+	}
+	s.v.Store(walkStep)
+}
+
+// leave tells the walk has left the step, then returns false if it has been abandoned meanwhile.
+func (s *walkState) leave() bool {
+	return s == nil || s.v.CompareAndSwap(walkStep, walkBetween) || s.v.CompareAndSwap(walkAwait, walkBetween)
+}
+
+// finish tells the walk has finished steps, which makes functions registered by OnCleanup abandoned as a step.
+func (s *walkState) finish() {
+	if s != nil {
+		s.v.CompareAndSwap(walkBetween, walkStep)
+	}
+}
+
+// awaited tells whether the walk is in a step to be awaited.
+func (s *walkState) awaited() bool {
+	return s.v.Load() == walkAwait
+}
+
+// abandon abandons the walk if it is in a step which can be abandoned, then returns whether it has been.
+func (s *walkState) abandon() bool {
+	return s.v.CompareAndSwap(walkStep, walkAbandoned)
+}
+
+// withCtxErr joins the context error with given error from a step if the context is done,
+// unless the error already tells about the context. Nil is kept as is, as the chain has completed.
+func withCtxErr(ctx context.Context, err error) error {
+	if ctxErr := ctxErr(ctx); ctxErr != nil && err != nil && !isContextErr(err) {
+		return errors.Join(ctxErr, err)
+	}
+	return err
+}
+
+// ctxErr returns the error of the context, which wraps the cause of the context if it is more specific,
+// so that both of them can be inspected by errors.Is.
+func ctxErr(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	switch {
+	case cause == nil || cause == err:
+		return err
+	case errors.Is(cause, err):
+		return cause
+	default:
+		return fmt.Errorf("%w: %w", err, cause)
+	}
+}
+
+// RunSync runs given Task as same as Task.Run does, but on the goroutine of the caller.
+// Unlike Task.Run, it never abandons a step in progress when the context is done; it returns after the step
+// finishes. Use it for chains of short steps, which the goroutine and channels of Task.Run cost more than steps.
+func RunSync(ctx context.Context, t Task) error {
+	return withCtxErr(ctx, walk(ctx, t, nil, nil, nil))
+}
+
+// Bind returns a function that runs given Task with the context, for those expecting func() error.
+// Cancel the context to cancel the run, as same as Task.Run.
+func Bind(ctx context.Context, t Task) func() error {
+	if isNil(t) {
+		t = With(nil)
+	}
+	return func() error {
+		return t.Run(ctx)
+	}
+}
+
+// walk executes every each step of the chain in order, until any of them fails or the context is done.
+// When state is given, it tells run what the walk is doing, and stops the walk once abandoned by run.
+// When pause is given, it holds the chain at the boundary of steps while paused.
+// Functions registered by OnCleanup are called once the chain finishes.
+func walk(ctx context.Context, t Task, rec *recorder, state *walkState, pause *pauser) (err error) {
+	ctx, cleanup := withCleanup(ctx)
+	defer func() {
+		state.finish()
+		if cleanupErr := cleanup(); cleanupErr != nil {
+			err = errors.Join(err, cleanupErr)
+		}
+	}()
+	limit, _ := ctx.Value(stepLimitKey{}).(*stepLimit)
+	perStep, _ := ctx.Value(perStepKey{}).(time.Duration)
+	index, strict := 0, isStrict(ctx)
+	defer func() {
+		if strict {
 			return
 		}
-
-		// check and dig next step if exists
-		if tt.Next() != nil {
-			tt = tt.Next()
-			goto dig
+		if p := recover(); p != nil { // from the chain itself rather than steps, such as Next of others
+			err = &PanicError{Index: index, Name: nameOf(t), Value: p, Stack: debug.Stack()}
+		}
+	}()
+	for ; !isNil(t); index, t = index+1, t.Next() {
+		if err := ctxErr(ctx); err != nil && !isShielded(t) { // context canceled or deadline exceeded, etc
+			return err
 		}
+		if !limit.take() {
+			return fmt.Errorf("%w: step %d", ErrStepLimitExceeded, index)
+		}
+		paused, err := pause.wait(ctx)
+		if err != nil {
+			return err
+		}
+		if lacksTime(ctx, t) {
+			rec.skip(infoOf(t, index), ErrSkippedDeadline)
+			continue
+		}
+		rec.start(infoOf(t, index), paused)
+		state.enter(t) // after observers are notified of the start, which an abandonment must not precede
+		stepCtx, deadline := withStepDeadline(withStepLogger(ctx, index, nameOf(t)), t, perStep)
+		panicked, err := invoke(rec.stepCtx(stepCtx, index), t, index)
+		err = deadline(err)
+		if !state.leave() { // run has returned already, reporting the step as ended by the context
+			return err
+		}
+		if !panicked && errors.Is(err, ErrStopChain) {
+			rec.end(index, false, nil)
+			rec.stop()
+			return nil
+		}
+		rec.end(index, panicked, err)
+		if err != nil {
+			if name, tags := nameOf(t), tagsOf(t); name != "" || len(tags) > 0 || identifies(t, err) {
+				return &StepError{Index: index, Name: name, Tags: tags, Err: err}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// isShielded tells whether given Task runs regardless of the context being done.
+func isShielded(t Task) bool {
+	node, ok := t.(*task)
+	return ok && node.shielded
+}
+
+// identifies tells whether given error from given Task is to be identified by StepError, even if not named.
+func identifies(t Task, err error) bool {
+	node, ok := t.(*task)
+	return ok && node.identify && errors.Is(err, ErrStepTimeout)
+}
 
-		// observed no error, thus signal success
-		done <- struct{}{}
+// lacksTime tells whether the context has less time remaining than given Task requires.
+func lacksTime(ctx context.Context, t Task) bool {
+	node, ok := t.(*task)
+	if !ok || node.requires <= 0 {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && deadline.Sub(now().Now()) < node.requires
+}
+
+// invoke executes the step of given Task at the index, converting panic into PanicError if any, unless strict.
+func invoke(ctx context.Context, t Task, index int) (panicked bool, err error) {
+	if isStrict(ctx) {
+		return false, exec(ctx, t)
+	}
+	defer func() {
+		if p := recover(); p != nil { // check panic content
+			panicked, err = true, &PanicError{Index: index, Name: nameOf(t), Value: p, Stack: debug.Stack()}
+		}
 	}()
+	return false, exec(ctx, t)
+}
 
-	select {
-	case <-ctx.Done(): // context done will always be faster if done ever happens
-		return ctx.Err()
-	case err := <-errChan: // propagate error
+// panicErr converts recovered panic content into an error.
+func panicErr(p any) error {
+	// check if panic content is either an error or a string
+	if err, ok := p.(error); ok { // error
 		return err
-	case <-done: // returns nil as we observed no error thus far
-		return nil
+	} else if str, isStr := p.(string); isStr { // string
+		return errors.New(str)
 	}
+	// not nil, not error, not string
+	return fmt.Errorf("%+v", p)
 }
 
 // Then implements Task.Then
 func (t *task) Then(next Task) Task {
-	// always copy a task into a new instance of task.
-	cp := &task{
-		step: t.step,
-		next: t.next,
-	}
-	// assign next task accordingly.
-	if cp.next == nil {
-		cp.next = next
-	} else {
-		cp.next = cp.next.Then(next) // keep immutability
+	// copy only the head, deferring the rest of the chain to Next, so that a chain is never copied per each Then.
+	// Others are left to keep their own immutability, as their Then is called on resolving.
+	head := t.clone()
+	if !isNil(next) {
+		head.pending = &pending{last: next, prev: t.pending}
+	}
+	return head
+}
+
+// ThenAll implements Task.ThenAll
+func (t *task) ThenAll(tasks ...Task) Task {
+	// chain from the last one, so that every each chain is copied only once.
+	var tail Task
+	for i := len(tasks) - 1; i >= 0; i-- {
+		switch {
+		case isNil(tasks[i]):
+		case tail == nil:
+			tail = tasks[i]
+		default:
+			tail = tasks[i].Then(tail)
+		}
+	}
+	if tail == nil {
+		return t
 	}
-	return cp
+	return t.Then(tail)
 }
 
 func (t *task) Step() Step {
@@ -126,5 +636,110 @@ func (t *task) Step() Step {
 }
 
 func (t *task) Next() Task {
+	if t.pending != nil {
+		return t.pending.resolve(t.next)
+	}
 	return t.next
 }
+
+// Fallback implements Task.Fallback
+func (t *task) Fallback(that Task) Task {
+	return withCtx(func(ctx context.Context) error {
+		err := t.Run(ctx)
+		if err == nil || that == nil || ctx.Err() != nil {
+			return err
+		}
+		return that.Run(ctx)
+	})
+}
+
+// OnSuccess implements Task.OnSuccess
+func (t *task) OnSuccess(f func()) Task {
+	node := withCtx(func(ctx context.Context) error {
+		err := t.Run(ctx)
+		if err == nil && f != nil {
+			f()
+		}
+		return err
+	})
+	node.await = true // Run returns promptly on cancellation, then the callback is called
+	return node
+}
+
+// OnFailure implements Task.OnFailure
+func (t *task) OnFailure(f func(err error)) Task {
+	node := withCtx(func(ctx context.Context) error {
+		err := t.Run(ctx)
+		if err != nil && f != nil {
+			f(err)
+		}
+		return err
+	})
+	node.await = true // Run returns promptly on cancellation, then the callback is called
+	return node
+}
+
+// MapError implements Task.MapError
+func (t *task) MapError(fn func(err error) error) Task {
+	if fn == nil {
+		return t
+	}
+	node := withCtx(func(ctx context.Context) error {
+		if err := t.Run(ctx); err != nil {
+			return fn(err)
+		}
+		return nil
+	})
+	node.await = true // Run returns promptly on cancellation, with the error to transform
+	return node
+}
+
+// Compact implements Task.Compact
+func (t *task) Compact() Task {
+	b := &Builder{}
+	for tt := Task(t); !isNil(tt); tt = tt.Next() {
+		if node, ok := tt.(*task); ok && node.noop && node.name == "" {
+			continue
+		}
+		b.nodes = append(b.nodes, tt)
+	}
+	return b.Task()
+}
+
+// Dedup implements Task.Dedup
+func (t *task) Dedup() Task {
+	b := &Builder{}
+	var last *task
+	for tt := Task(t); !isNil(tt); tt = tt.Next() {
+		node, _ := tt.(*task)
+		if node != nil && last != nil && node.duplicates(last) {
+			continue
+		}
+		last = node
+		b.nodes = append(b.nodes, tt)
+	}
+	return b.Task()
+}
+
+// Reverse implements Task.Reverse
+func (t *task) Reverse() Task {
+	b := &Builder{}
+	b.Append(t)
+	for i, j := 0, len(b.nodes)-1; i < j; i, j = i+1, j-1 {
+		b.nodes[i], b.nodes[j] = b.nodes[j], b.nodes[i]
+	}
+	return b.Task()
+}
+
+// String implements fmt.Stringer, which describes the chain when any of tasks is named, or counts them otherwise.
+func (t *task) String() string {
+	for tt := Task(t); !isNil(tt); tt = tt.Next() {
+		if nameOf(tt) != "" {
+			return Describe(t)
+		}
+	}
+	if n := Count(t); n != 1 {
+		return fmt.Sprintf("task[%d steps]", n)
+	}
+	return "task[1 step]"
+}
@@ -3,7 +3,10 @@ package grace
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -175,3 +178,832 @@ func TestTask_Run_MustReturnError_WhenWorkError(t *testing.T) {
 
 	assert.ErrorContains(t, before.Then(tsk).Run(context.Background()), "i am here")
 }
+
+func TestTask_Fallback_MustRunThat_WhenStepError(t *testing.T) {
+	t.Parallel()
+	primary, secondary := 0, 0
+	tsk := With(func() error {
+		primary++
+		return errors.New("primary down")
+	}).Fallback(WithNoErr(func() { secondary++ }))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 1, primary)
+	assert.Equal(t, 1, secondary)
+
+	tsk = With(func() error { return errors.New("primary down") }).
+		Fallback(With(func() error { return errors.New("secondary down") }))
+	assert.ErrorContains(t, tsk.Run(context.Background()), "secondary down")
+}
+
+func TestTask_Fallback_MustNotRunThat_WhenSucceeded(t *testing.T) {
+	t.Parallel()
+	secondary := 0
+	tsk := With(nil).Fallback(WithNoErr(func() { secondary++ }))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 0, secondary)
+}
+
+func TestTask_Fallback_MustNotRunThat_WhenCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	secondary := 0
+	tsk := With(func() error {
+		cancel()
+		return context.Canceled
+	}).Fallback(WithNoErr(func() { secondary++ }))
+
+	assert.ErrorIs(t, tsk.Run(ctx), context.Canceled)
+	assert.Equal(t, 0, secondary)
+}
+
+func TestTask_String_MustCount_WhenAnonymous(t *testing.T) {
+	t.Parallel()
+	var nilTask *task
+	assert.Equal(t, "task[1 step]", fmt.Sprint(With(nil)))
+	assert.Equal(t, "task[3 steps]", fmt.Sprint(With(nil).Then(With(nil)).Then(&task{})))
+	assert.Equal(t, "task[0 steps]", nilTask.String())
+}
+
+func TestTask_String_MustDescribe_WhenNamed(t *testing.T) {
+	t.Parallel()
+	tsk := With(nil).Then(Named("drain", With(nil))).Then(Named("close", With(nil)))
+	assert.Equal(t, "#0 -> drain -> close (3 steps)", fmt.Sprint(tsk))
+}
+
+func TestTask_String_MustNotRunSteps(t *testing.T) {
+	t.Parallel()
+	count := 0
+	tsk := Named("count", WithNoErr(func() { count++ }))
+	for i := 0; i < 99; i++ {
+		tsk = WithNoErr(func() { count++ }).Then(tsk)
+	}
+	assert.Contains(t, fmt.Sprint(tsk), "count (100 steps)")
+	assert.Equal(t, 0, count)
+}
+
+func TestRecover_MustMapPanic(t *testing.T) {
+	t.Parallel()
+	errInvalid := errors.New("invalid input")
+	tsk := Recover(func() error {
+		panic("validation failed")
+	}, func(p any) error {
+		return fmt.Errorf("%w: %v", errInvalid, p)
+	})
+
+	err := tsk.Run(context.Background())
+	assert.ErrorIs(t, err, errInvalid)
+	assert.ErrorContains(t, err, "validation failed")
+}
+
+func TestRecover_MustContinue_WhenPanicSwallowed(t *testing.T) {
+	t.Parallel()
+	reached := false
+	tsk := Recover(func() error {
+		panic("ignored")
+	}, func(any) error {
+		return nil
+	}).Then(WithNoErr(func() { reached = true }))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.True(t, reached)
+}
+
+func TestRecover_MustKeepResult_WhenNoPanic(t *testing.T) {
+	t.Parallel()
+	called := false
+	onPanic := func(any) error {
+		called = true
+		return nil
+	}
+	assert.ErrorContains(t, Recover(func() error { return errors.New("failure") }, onPanic).Run(context.Background()), "failure")
+	assert.NoError(t, Recover(nil, onPanic).Run(context.Background()))
+	assert.False(t, called)
+	assert.ErrorContains(t, Recover(func() error { panic("default") }, nil).Run(context.Background()), "default")
+}
+
+func TestErrStopChain_MustStopChainSuccessfully(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	tsk := With(store(valueOf(1))).
+		Then(With(func() error { return ErrStopChain })).
+		Then(With(store(valueOf(3))))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1}, sink.Values())
+
+	report, err := tsk.RunReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, StepSucceeded, report.Steps[1].Status)
+	assert.NoError(t, report.Steps[1].Err)
+	assert.Equal(t, StepSkipped, report.Steps[2].Status)
+}
+
+func TestErrStopChain_MustStop_WhenWrapped(t *testing.T) {
+	t.Parallel()
+	reached := false
+	tsk := With(func() error { return fmt.Errorf("nothing to do: %w", ErrStopChain) }).
+		Then(WithNoErr(func() { reached = true }))
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.False(t, reached)
+}
+
+func TestErrStopChain_MustFail_WhenPanicked(t *testing.T) {
+	t.Parallel()
+	tsk := WithNoErr(func() { panic(ErrStopChain) })
+	assert.ErrorIs(t, tsk.Run(context.Background()), ErrStopChain)
+}
+
+func TestRunSync_MustRunOnCallerGoroutine(t *testing.T) {
+	t.Parallel()
+	order := make([]int, 0)
+	tsk := WithNoErr(func() { order = append(order, 1) }).Then(WithNoErr(func() { order = append(order, 2) }))
+
+	assert.NoError(t, RunSync(context.Background(), tsk))
+	assert.Equal(t, []int{1, 2}, order, "must be visible without synchronization")
+	assert.NoError(t, RunSync(context.Background(), nil))
+}
+
+func TestRunSync_MustPropagateErrorAndPanic(t *testing.T) {
+	t.Parallel()
+	assert.ErrorContains(t, RunSync(context.Background(), With(func() error { return errors.New("failure") })), "failure")
+	assert.ErrorContains(t, RunSync(context.Background(), WithNoErr(func() { panic("panicked") })), "panicked")
+	assert.NoError(t, RunSync(context.Background(), With(func() error { return ErrStopChain }).Then(WithNoErr(func() { panic("unreachable") }))))
+}
+
+func TestRunSync_MustCheckContextBetweenSteps(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	reached := false
+	tsk := WithNoErr(cancel).Then(WithNoErr(func() { reached = true }))
+
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.Canceled)
+	assert.False(t, reached)
+}
+
+func TestTask_Run_MustBeSafeForConcurrentRuns(t *testing.T) {
+	t.Parallel()
+	count := int32(0)
+	step := WithNoErr(func() { atomic.AddInt32(&count, 1) })
+	failing := With(func() error { return errors.New("failure") })
+	tsk := Named("first", step).
+		Then(All(step, step)).
+		Then(failing.Fallback(step)).
+		Then(WithBackoff(func() error { return step.Run(context.Background()) }, BackoffConfig{Attempts: 2})).
+		Then(WithTimeout(func() error { return step.Run(context.Background()) }, time.Minute))
+
+	const runs = 100
+	wg := sync.WaitGroup{}
+	for i := 0; i < runs; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, tsk.Run(context.Background()))
+		}()
+		go func() {
+			defer wg.Done()
+			report, err := tsk.RunReport(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, report.Steps, 5)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(runs*2*6), atomic.LoadInt32(&count))
+}
+
+func TestTask_Then_MustCopyLongChainIteratively(t *testing.T) {
+	t.Parallel()
+	count := int32(0)
+	tsk := With(nil)
+	for i := 1; i < 100_000; i++ {
+		tsk = With(nil).Then(tsk)
+	}
+	chained := tsk.Then(WithNoErr(func() { atomic.AddInt32(&count, 1) }))
+
+	assert.Equal(t, 100_000, Count(tsk))
+	assert.Equal(t, 100_001, Count(chained))
+	assert.NoError(t, chained.Run(context.Background()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestTask_Then_MustAppendLongChain_Linearly(t *testing.T) {
+	t.Parallel()
+	count := int32(0)
+	tsk := With(nil)
+	for i := 1; i < 100_000; i++ {
+		tsk = tsk.Then(WithNoErr(func() { atomic.AddInt32(&count, 1) }))
+	}
+
+	assert.Equal(t, 100_000, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, int32(99_999), atomic.LoadInt32(&count))
+}
+
+func TestTask_Then_MustBeImmutable_WhenBranched(t *testing.T) {
+	t.Parallel()
+	sink, put := Collect[int]()
+	base := With(put(valueOf(1))).Then(With(put(valueOf(2))))
+	left, right := base.Then(With(put(valueOf(3)))), base.Then(With(put(valueOf(4))).Then(With(put(valueOf(5)))))
+	grown := left.Then(With(put(valueOf(6))))
+
+	for _, tsk := range []Task{right, grown, left, base} {
+		assert.NoError(t, tsk.Run(context.Background()))
+	}
+	assert.Equal(t, []int{1, 2, 4, 5, 1, 2, 3, 6, 1, 2, 3, 1, 2}, sink.Values())
+	assert.Equal(t, 2, Count(base))
+	assert.Equal(t, 3, Count(left))
+}
+
+func TestTask_Then_MustResolveOnce_WhenRunConcurrently(t *testing.T) {
+	t.Parallel()
+	count := int32(0)
+	tsk := With(nil)
+	for i := 1; i < 1_000; i++ {
+		tsk = tsk.Then(WithNoErr(func() { atomic.AddInt32(&count, 1) }))
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, tsk.Run(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(8*999), atomic.LoadInt32(&count))
+}
+
+// foreign is a Task implemented outside of this package.
+type foreign struct {
+	Task
+}
+
+func (f foreign) Then(that Task) Task {
+	return foreign{f.Task.Then(that)}
+}
+
+func TestTask_Then_MustDelegateToOthers(t *testing.T) {
+	t.Parallel()
+	tsk := With(nil).Then(foreign{With(nil)}).Then(With(nil))
+	_, ok := tsk.Next().(foreign)
+	assert.True(t, ok)
+	assert.Equal(t, 3, Count(tsk))
+}
+
+func TestTask_Run_MustJoinContextError_WithStepError(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	for i := 0; i < 100; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		tsk := With(func() error {
+			cancel()
+			return failure
+		})
+
+		err := tsk.Run(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, failure)
+		err = RunSync(ctx, With(nil).Fallback(With(func() error { return failure })))
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
+
+func TestTask_Run_MustNotJoinContextError_WhenPreCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	failure := errors.New("failure")
+	tsk := withCtx(func(context.Context) error { return failure })
+
+	// step is never run on done context, hence there is only the context error
+	assert.Equal(t, context.Canceled, tsk.Run(ctx))
+	err := withCtxErr(ctx, exec(ctx, tsk))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestWithCtx_MustReceiveRunContext(t *testing.T) {
+	t.Parallel()
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	var received any
+	tsk := WithCtx(func(ctx context.Context) error {
+		received = ctx.Value(key{})
+		return nil
+	})
+
+	assert.NoError(t, tsk.Run(ctx))
+	assert.Equal(t, "value", received)
+	assert.NoError(t, WithCtx(nil).Run(ctx))
+}
+
+func TestIntercept_MustWrapEveryStep(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[string]()
+	tsk := Named("a", With(store(valueOf("a")))).Then(WithCtx(func(context.Context) error { return store(valueOf("b"))() }))
+	intercepted := Intercept(tsk, func(info StepInfo, step StepCtx) StepCtx {
+		return func(ctx context.Context) error {
+			_ = store(valueOf(fmt.Sprintf("before %d %s", info.Index, info.Name)))()
+			return step(ctx)
+		}
+	})
+
+	assert.Equal(t, Describe(tsk), Describe(intercepted))
+	assert.NoError(t, intercepted.Run(context.Background()))
+	assert.Equal(t, []string{"before 0 a", "a", "before 1 ", "b"}, sink.Values())
+
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []string{"a", "b"}, sink.Values()[4:], "must not affect given chain")
+}
+
+func TestTask_Run_MustReportContextCause(t *testing.T) {
+	t.Parallel()
+	errRollout := errors.New("deploy rollout")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	tsk := WithNoErr(func() { cancel(errRollout) }).Then(With(nil))
+
+	err := tsk.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errRollout)
+	assert.EqualError(t, err, "context canceled: deploy rollout")
+
+	err = RunSync(ctx, tsk)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errRollout)
+
+	ctx, cancel = context.WithCancelCause(context.Background())
+	cancel(nil)
+	assert.Equal(t, context.Canceled, tsk.Run(ctx), "must keep bare error without cause")
+}
+
+func TestTask_Run_MustReportContextCause_WhenCanceledInProgress(t *testing.T) {
+	t.Parallel()
+	errDrain := errors.New("drain timeout")
+	ctx, cancel := context.WithTimeoutCause(context.Background(), time.Millisecond*20, errDrain)
+	defer cancel()
+	tsk := WithNoErr(func() { time.Sleep(time.Second) })
+
+	err := tsk.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errDrain)
+
+	ctx, cancel = context.WithTimeoutCause(context.Background(), time.Millisecond*20, errDrain)
+	defer cancel()
+	report, err := tsk.RunReport(ctx)
+	assert.ErrorIs(t, err, errDrain)
+	assert.ErrorIs(t, report.Steps[0].Err, errDrain)
+
+	ctx, cancelCause := context.WithCancelCause(context.Background())
+	h := Start(ctx, tsk)
+	cancelCause(errDrain)
+	assert.ErrorIs(t, h.Wait(), errDrain)
+}
+
+func TestTask_Run_MustPropagateCause_ThroughDerivedContexts(t *testing.T) {
+	t.Parallel()
+	errShutdown := errors.New("SIGTERM")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errShutdown)
+
+	err := exec(ctx, WithTimeout(func() error { select {} }, time.Minute))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errShutdown)
+
+	var multi *MultiError
+	assert.ErrorAs(t, exec(ctx, All(With(nil))), &multi)
+	assert.ErrorIs(t, multi.Err, errShutdown)
+}
+
+func TestRequiresAtLeast_MustSkip_WhenDeadlineTooClose(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	slow, next := &atomic.Bool{}, &atomic.Bool{}
+	tsk := RequiresAtLeast(time.Minute, Named("slow", WithNoErr(func() { slow.Store(true) }))).
+		Then(WithNoErr(func() { next.Store(true) }))
+
+	report, err := tsk.RunReport(ctx)
+	assert.NoError(t, err)
+	assert.False(t, slow.Load())
+	assert.True(t, next.Load())
+	assert.Equal(t, StepReport{Index: 0, Name: "slow", Status: StepSkipped, Err: ErrSkippedDeadline}, report.Steps[0])
+	assert.Equal(t, StepSucceeded, report.Steps[1].Status)
+}
+
+func TestRequiresAtLeast_MustRun_WhenEnoughTimeOrNoDeadline(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	count := &atomic.Int32{}
+	tsk := RequiresAtLeast(time.Second, WithNoErr(func() { count.Add(1) }))
+
+	assert.NoError(t, tsk.Run(ctx))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.NoError(t, RunSync(ctx, tsk))
+	assert.Equal(t, int32(3), count.Load())
+}
+
+func TestRequiresAtLeast_MustSkipWholeChain_WhenChained(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	count := &atomic.Int32{}
+	step := WithNoErr(func() { count.Add(1) })
+
+	assert.NoError(t, RunSync(ctx, RequiresAtLeast(time.Minute, step.Then(step)).Then(step)))
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestBind_MustRunWithBoundContext(t *testing.T) {
+	t.Parallel()
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	var values []any
+	run := Bind(ctx, WithCtx(func(ctx context.Context) error {
+		values = append(values, ctx.Value(key{}))
+		return nil
+	}))
+
+	assert.NoError(t, run())
+	assert.Equal(t, []any{"value"}, values)
+
+	cancel()
+	assert.ErrorIs(t, run(), context.Canceled)
+	assert.Len(t, values, 1)
+	assert.NoError(t, Bind(context.Background(), nil)())
+}
+
+func TestWithValidate_MustNotRunStep_WhenValidationFails(t *testing.T) {
+	t.Parallel()
+	invalid := errors.New("invalid")
+	called := false
+	step := func() error {
+		called = true
+		return nil
+	}
+
+	assert.ErrorIs(t, WithValidate(func() error { return invalid }, step).Run(context.Background()), invalid)
+	assert.False(t, called)
+
+	err := WithValidate(func() error { panic("panicked") }, step).Run(context.Background())
+	assert.EqualError(t, err, "panicked")
+	assert.False(t, called)
+}
+
+func TestWithValidate_MustRunStep_WhenValidationPasses(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	validated := false
+	tsk := WithValidate(func() error {
+		validated = true
+		return nil
+	}, func() error { return failure })
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.True(t, validated)
+	assert.NoError(t, WithValidate(nil, nil).Run(context.Background()))
+}
+
+func TestTask_Compact_MustRemoveNoops(t *testing.T) {
+	t.Parallel()
+	var order []int
+	step := func(i int) Task { return WithNoErr(func() { order = append(order, i) }) }
+	tsk := With(nil).Then(step(0)).Then(With(nil)).Then(WithCtx(nil)).Then(Named("kept", With(nil))).Then(step(1))
+
+	compacted := tsk.Compact()
+	assert.Equal(t, 6, Count(tsk))
+	assert.Equal(t, 3, Count(compacted))
+	assert.Equal(t, "#0 -> kept -> #2 (3 steps)", Describe(compacted))
+	assert.NoError(t, compacted.Run(context.Background()))
+	assert.Equal(t, []int{0, 1}, order)
+	assert.Equal(t, 6, Count(tsk), "must not affect the original")
+}
+
+func TestTask_Compact_MustKeepStepsWithSideEffects(t *testing.T) {
+	t.Parallel()
+	tsk := WithNoErr(nil).Then(With(func() error { return nil })).Then(Intercept(With(nil), func(_ StepInfo, step StepCtx) StepCtx {
+		return step
+	}))
+	assert.Equal(t, 3, Count(tsk.Compact()))
+
+	compacted := With(nil).Then(With(nil)).Compact()
+	assert.Equal(t, 1, Count(compacted))
+	assert.NoError(t, compacted.Run(context.Background()))
+}
+
+func TestTask_Dedup_MustRunDuplicatedInstanceOnce(t *testing.T) {
+	t.Parallel()
+	count := 0
+	step := WithNoErr(func() { count++ })
+	tsk := With(nil).Then(step).Then(step).Then(step.Then(With(nil)))
+
+	assert.Equal(t, 3, Count(tsk.Dedup()))
+	assert.NoError(t, tsk.Dedup().Run(context.Background()))
+	assert.Equal(t, 1, count)
+
+	count = 0
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 3, count, "must not affect the receiver")
+}
+
+func TestTask_Dedup_MustKeepCopies_NamedOrTaggedDifferently(t *testing.T) {
+	t.Parallel()
+	count := 0
+	step := WithNoErr(func() { count++ })
+	tsk := Named("a", step).Then(Named("b", step)).Then(Named("b", step)).
+		Then(WithTags(step, map[string]string{"k": "v"})).Then(WithTags(step, map[string]string{"k": "v"})).Dedup()
+
+	assert.Equal(t, []string{"a", "b", ""}, []string{nameOf(tsk), nameOf(tsk.Next()), nameOf(tsk.Next().Next())})
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 3, count)
+}
+
+func TestTask_Dedup_MustPreserveOrder_Otherwise(t *testing.T) {
+	t.Parallel()
+	sink, store := Collect[int]()
+	one, two := With(store(valueOf(1))), With(store(valueOf(2)))
+	tsk := one.Then(two).Then(one).Then(With(store(valueOf(2)))).Then(foreign{one}).Then(foreign{one}).Dedup()
+
+	assert.Equal(t, 6, Count(tsk))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []int{1, 2, 1, 2, 1, 1}, sink.Values())
+}
+
+func TestTask_Reverse_MustRunInReverseOrder(t *testing.T) {
+	t.Parallel()
+	var order []string
+	step := func(name string) Task {
+		return Named(name, WithNoErr(func() { order = append(order, name) }))
+	}
+	tsk := step("open").Then(step("lock")).Then(step("map"))
+
+	reversed := tsk.Reverse()
+	assert.NoError(t, reversed.Run(context.Background()))
+	assert.Equal(t, []string{"map", "lock", "open"}, order)
+	assert.Equal(t, "open -> lock -> map (3 steps)", Describe(tsk), "must not affect the original")
+	assert.Equal(t, "map -> lock -> open (3 steps)", Describe(reversed))
+	assert.Equal(t, 1, Count(With(nil).Reverse()))
+}
+
+// brokenNext is a Task implemented outside of this package, which panics on Next.
+type brokenNext struct {
+	Task
+}
+
+func (b brokenNext) Next() Task {
+	panic("broken next")
+}
+
+func TestTask_Run_MustRecoverPanic_FromNextOfOthers(t *testing.T) {
+	t.Parallel()
+	called := false
+	tsk := brokenNext{WithNoErr(func() { called = true })}
+
+	err := run(context.Background(), tsk, nil, nil)
+	assert.True(t, called)
+	assert.EqualError(t, err, "broken next")
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, 0, panicErr.Index)
+	}
+
+	h := Start(context.Background(), tsk)
+	assert.EqualError(t, h.Wait(), "broken next")
+	assert.Equal(t, StepSucceeded, h.Report().Steps[0].Status)
+}
+
+func TestTask_ThenAll_MustChainInOrder(t *testing.T) {
+	t.Parallel()
+	var order []string
+	step := func(name string) Task {
+		return Named(name, WithNoErr(func() { order = append(order, name) }))
+	}
+	head := step("a")
+	tail := step("c").Then(step("d"))
+
+	tsk := head.ThenAll(step("b"), nil, tail, step("e"))
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, order)
+	assert.Equal(t, "a -> b -> c -> d -> e (5 steps)", Describe(tsk))
+	assert.Equal(t, 1, Count(head), "must not affect the receiver")
+	assert.Equal(t, 2, Count(tail), "must not affect given tasks")
+}
+
+func TestTask_ThenAll_MustReturnReceiver_WhenEmpty(t *testing.T) {
+	t.Parallel()
+	tsk := With(nil)
+	assert.Same(t, tsk, tsk.ThenAll())
+	assert.Same(t, tsk, tsk.ThenAll(nil, nil))
+}
+
+func TestTask_OnSuccess_MustCallOnce_WhenChainSucceeded(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	tsk := With(nil).Then(With(nil)).OnSuccess(func() { calls++ })
+	assert.NoError(t, tsk.Run(context.Background()))
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	tsk = With(nil).Then(With(func() error { return errors.New("failure") })).OnSuccess(func() { calls++ })
+	assert.Error(t, tsk.Run(context.Background()))
+	assert.Zero(t, calls)
+	assert.NoError(t, With(nil).OnSuccess(nil).Run(context.Background()))
+}
+
+func TestTask_OnFailure_MustCallOnce_WithError(t *testing.T) {
+	t.Parallel()
+	var errs []error
+	onFailure := func(err error) { errs = append(errs, err) }
+	failure := errors.New("failure")
+
+	assert.NoError(t, With(nil).OnFailure(onFailure).Run(context.Background()))
+	assert.Empty(t, errs)
+
+	err := With(nil).Then(With(func() error { return failure })).OnFailure(onFailure).Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []error{err}, errs)
+
+	errs = nil
+	err = WithNoErr(func() { panic("panicked") }).OnFailure(onFailure).Run(context.Background())
+	var panicErr *PanicError
+	if assert.Len(t, errs, 1) {
+		assert.ErrorAs(t, errs[0], &panicErr)
+		assert.Equal(t, err, errs[0])
+	}
+
+	errs = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	err = WithNoErr(cancel).Then(With(nil)).OnFailure(onFailure).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], context.Canceled)
+	}
+}
+
+func TestTask_OnFailure_MustReturnPanic_FromCallback(t *testing.T) {
+	t.Parallel()
+	tsk := With(func() error { return errors.New("failure") }).OnFailure(func(error) { panic("callback") })
+	err := tsk.Run(context.Background())
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.EqualError(t, err, "callback")
+
+	err = With(nil).OnSuccess(func() { panic("callback") }).Run(context.Background())
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestTask_OnFailure_MustReturnPanic_FromCallback_WhenCanceledInProgress(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk := WithNoErr(func() {
+		cancel()
+		time.Sleep(drainWait * 5) // abandoned
+	}).OnFailure(func(error) { panic("callback") })
+
+	err := tsk.Run(ctx)
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorContains(t, err, "callback")
+}
+
+func TestTask_MapError_MustTransformStepErrorsAndPanics(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	withID := func(err error) error { return fmt.Errorf("request 42: %w", err) }
+
+	err := With(nil).Then(With(func() error { return failure })).MapError(withID).Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.EqualError(t, err, "request 42: failure")
+
+	err = WithNoErr(func() { panic("panicked") }).MapError(withID).Run(context.Background())
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.EqualError(t, err, "request 42: panicked")
+
+	called := false
+	assert.NoError(t, With(nil).MapError(func(err error) error { called = true; return err }).Run(context.Background()))
+	assert.False(t, called, "must not be given nil")
+	assert.NoError(t, With(func() error { return failure }).MapError(func(error) error { return nil }).Run(context.Background()))
+}
+
+func TestTask_MapError_MustExemptContextErr_WhenAsked(t *testing.T) {
+	t.Parallel()
+	masked := errors.New("masked")
+	mask := func(error) error { return masked }
+	canceling := func() (context.Context, Task) {
+		ctx, cancel := context.WithCancel(context.Background())
+		return ctx, WithCtx(func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+	}
+
+	ctx, tsk := canceling()
+	assert.ErrorIs(t, tsk.MapError(mask).Run(ctx), masked)
+	ctx, tsk = canceling()
+	err := tsk.MapError(ExemptContextErr(mask)).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, masked)
+	assert.ErrorIs(t, With(func() error { return errors.New("failure") }).MapError(ExemptContextErr(mask)).Run(context.Background()), masked)
+}
+
+func TestTask_MapError_MustTransformContextErr_WhenCanceledInProgress(t *testing.T) {
+	t.Parallel()
+	masked := errors.New("masked")
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk := WithNoErr(func() {
+		cancel()
+		time.Sleep(drainWait * 5) // abandoned
+	}).MapError(func(err error) error { return fmt.Errorf("%w: %w", masked, err) })
+
+	err := tsk.Run(ctx)
+	assert.ErrorIs(t, err, masked)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// ctxKey is a context key of tests.
+type ctxKey struct{}
+
+func TestTask_Run_MustPropagateContext_AtDepth(t *testing.T) {
+	t.Parallel()
+	seen := 0
+	step := func(ctx context.Context) error {
+		if ctx.Value(ctxKey{}) == "value" {
+			seen++
+		}
+		return nil
+	}
+	tsk := WithCtx(step)
+	for i := 1; i < 10_000; i++ {
+		tsk = WithCtx(step).Then(tsk) // prepending costs O(1), unlike appending by Then
+	}
+
+	assert.NoError(t, tsk.Run(context.WithValue(context.Background(), ctxKey{}, "value")))
+	assert.Equal(t, 10_000, seen)
+}
+
+func TestTask_Run_MustCancel_AtDepth(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ran := 0
+	b := &Builder{}
+	for i := 0; i < 10_000; i++ {
+		i := i
+		b.Append(WithNoErr(func() {
+			if ran++; i == 9_000 {
+				cancel()
+			}
+		}))
+	}
+
+	assert.ErrorIs(t, RunSync(ctx, b.Task()), context.Canceled)
+	assert.Equal(t, 9_001, ran)
+}
+
+// thenEager chains given tasks as Then did before deferring the copy to Next, copying the whole chain on every each call.
+func thenEager(t *task, next Task) *task {
+	head := t.clone()
+	for tail := head; ; {
+		if isNil(tail.next) {
+			tail.next = next
+			break
+		}
+		node := tail.next.(*task).clone()
+		tail.next, tail = node, node
+	}
+	return head
+}
+
+func BenchmarkTask_Then_Append(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tsk := With(nil)
+		for j := 1; j < 1_000; j++ {
+			tsk = tsk.Then(With(nil))
+		}
+		_ = Count(tsk)
+	}
+}
+
+func BenchmarkTask_Then_Append_Eager(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tsk := With(nil).(*task)
+		for j := 1; j < 1_000; j++ {
+			tsk = thenEager(tsk, With(nil))
+		}
+		_ = Count(tsk)
+	}
+}
+
+func BenchmarkBuilder_Append(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		builder := &Builder{}
+		for j := 0; j < 1_000; j++ {
+			builder.Append(With(nil))
+		}
+		_ = builder.Task()
+	}
+}
@@ -0,0 +1,111 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrStepTimeout is the cause of the context of a step timed out by StepTimeout,
+// which tells the timeout of the step from the deadline of the context given to Run.
+var ErrStepTimeout = errors.New("step timeout")
+
+// WithTimeout returns new Task that fails with context.DeadlineExceeded when given step does not finish in time.
+// The deadline is derived from the context given to Run, thus the earlier one between them applies.
+// As the step is unaware of the context, it keeps running in background after the deadline.
+func WithTimeout(step Step, timeout time.Duration) Task {
+	if step == nil {
+		return With(nil)
+	}
+	return withCtx(func(ctx context.Context) error {
+		return runTimeout(ctx, func(context.Context) error { return step() }, timeout, nil)
+	})
+}
+
+// WithDeadline returns new Task that runs given step with the context of the timeout, derived from the context given
+// to Run. Unlike WithTimeout, the step is never abandoned; it is trusted to respect the context, and awaited for.
+func WithDeadline(step StepCtx, timeout time.Duration) Task {
+	if step == nil {
+		return With(nil)
+	}
+	t := withCtx(func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return step(ctx)
+	})
+	t.await = true
+	return t
+}
+
+// Sleep returns new Task that waits for given duration, or fails with the error of the context when it is done first.
+func Sleep(d time.Duration) Task {
+	t := withCtx(func(ctx context.Context) error {
+		return sleep(ctx, d)
+	})
+	t.await = true // returns promptly on cancellation
+	return t
+}
+
+// At returns new Task that waits until given time, then runs given Task. When the time has passed already, the Task
+// runs right away. When the context is done during the wait, it fails with the error of the context without running
+// the Task. The time is of the wall clock, such as to run a maintenance chain at 02:00.
+func At(at time.Time, t Task) Task {
+	if isNil(t) {
+		t = With(nil)
+	}
+	node := withCtx(func(ctx context.Context) error {
+		if err := sleep(ctx, at.Sub(now().Now())); err != nil {
+			return err
+		}
+		return t.Run(ctx)
+	})
+	node.await = true // returns promptly on cancellation
+	return node
+}
+
+// StepOption configures a task added by Builder.Add.
+type StepOption func(t *task) *task
+
+// StepTimeout returns StepOption that runs the task under its own deadline of the timeout, derived from the context
+// given to Run. When the task times out, the error wraps ErrStepTimeout along with context.DeadlineExceeded,
+// and identifies the task by StepError even if the task is not named. Other errors are returned as they are.
+func StepTimeout(timeout time.Duration) StepOption {
+	return func(t *task) *task {
+		cause := fmt.Errorf("%w after %v", ErrStepTimeout, timeout)
+		run := func(ctx context.Context) error {
+			return runTimeout(ctx, func(ctx context.Context) error { return exec(ctx, t) }, timeout, cause)
+		}
+		node := t.clone()
+		node.step = func() error { return run(context.Background()) }
+		node.run, node.noop, node.identify = run, false, true
+		return node
+	}
+}
+
+// runTimeout runs the step in a separate goroutine with the context of the timeout, which is canceled by the cause.
+// It returns as soon as the context is done, while the step keeps running in background if unaware of the context.
+func runTimeout(ctx context.Context, step StepCtx, timeout time.Duration, cause error) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, cause)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				if isStrict(ctx) {
+					panic(p)
+				}
+				errChan <- panicErr(p)
+			}
+		}()
+		errChan <- step(ctx)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctxErr(ctx)
+	case err := <-errChan:
+		return err
+	}
+}
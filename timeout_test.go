@@ -0,0 +1,143 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout_MustFail_WhenStepTakesTooLong(t *testing.T) {
+	t.Parallel()
+	tsk := WithTimeout(func() error {
+		time.Sleep(time.Second)
+		return nil
+	}, time.Millisecond*50)
+
+	start := time.Now()
+	assert.ErrorIs(t, tsk.Run(context.Background()), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+}
+
+func TestWithTimeout_MustApplyParentDeadline_WhenEarlier(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	tsk := WithTimeout(func() error {
+		time.Sleep(time.Second * 2)
+		return nil
+	}, time.Minute)
+
+	// execute the step directly, so that Run does not observe the parent deadline on behalf of the step
+	start := time.Now()
+	assert.ErrorIs(t, exec(ctx, tsk), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWithTimeout_MustPropagateResult_WhenInTime(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, WithTimeout(func() error { return nil }, time.Second).Run(context.Background()))
+
+	tsk := WithTimeout(func() error { return errors.New("failure") }, time.Second)
+	assert.ErrorContains(t, tsk.Run(context.Background()), "failure")
+
+	tsk = WithTimeout(func() error { panic("panicked") }, time.Second)
+	assert.ErrorContains(t, tsk.Run(context.Background()), "panicked")
+
+	assert.NoError(t, WithTimeout(nil, time.Second).Run(context.Background()))
+}
+
+func TestWithDeadline_MustGiveStepContextOfTimeout(t *testing.T) {
+	t.Parallel()
+	var observed error
+	tsk := WithDeadline(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		<-ctx.Done()
+		observed = ctx.Err()
+		return errors.New("gave up")
+	}, time.Millisecond*20)
+
+	assert.EqualError(t, tsk.Run(context.Background()), "gave up")
+	assert.ErrorIs(t, observed, context.DeadlineExceeded)
+}
+
+func TestWithDeadline_MustAwaitStep_WhenParentDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := false
+	tsk := WithDeadline(func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		time.Sleep(time.Millisecond * 50) // longer than Run waits for steps not awaited
+		finished = true
+		return nil
+	}, time.Hour)
+
+	assert.NoError(t, tsk.Run(ctx), "the step reports its own result")
+	assert.True(t, finished)
+	assert.NoError(t, WithDeadline(nil, time.Second).Run(context.Background()))
+}
+
+func TestSleep_MustWaitForDuration(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	assert.NoError(t, Sleep(time.Millisecond*20).Run(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*20)
+	assert.NoError(t, Sleep(0).Run(context.Background()))
+}
+
+func TestAt_MustRunAtTime_WithoutSleeping(t *testing.T) { // not parallel, as the clock is replaced
+	c := useFakeClock(t)
+	ran := make(chan time.Time, 1)
+	tsk := At(c.Now().Add(time.Hour*2), WithNoErr(func() { ran <- c.Now() }))
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- RunSync(context.Background(), tsk) }()
+	c.AwaitWaiters(t, 1)
+	c.Advance(time.Hour)
+	select {
+	case <-ran:
+		t.Fatal("must not run before the time")
+	default:
+	}
+	c.Advance(time.Hour)
+
+	assert.NoError(t, <-errChan)
+	assert.Equal(t, time.Date(2022, 8, 30, 2, 0, 0, 0, time.UTC), <-ran)
+}
+
+func TestAt_MustRunRightAway_WhenTimeHasPassed(t *testing.T) {
+	c := useFakeClock(t)
+	ran := false
+	assert.NoError(t, At(c.Now().Add(-time.Hour), WithNoErr(func() { ran = true })).Run(context.Background()))
+	assert.True(t, ran)
+}
+
+func TestAt_MustFail_WhenContextDoneDuringWait(t *testing.T) {
+	c := useFakeClock(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	tsk := At(c.Now().Add(time.Hour), WithNoErr(func() { ran = true }))
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- RunSync(ctx, tsk) }()
+	c.AwaitWaiters(t, 1)
+	cancel()
+	assert.ErrorIs(t, <-errChan, context.Canceled)
+	assert.False(t, ran)
+}
+
+func TestSleep_MustReturnPromptly_WhenContextDone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer time.AfterFunc(time.Millisecond*20, cancel).Stop()
+	reached := false
+	tsk := Sleep(time.Hour).Then(WithNoErr(func() { reached = true }))
+
+	start := time.Now()
+	assert.ErrorIs(t, RunSync(ctx, tsk), context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, reached)
+}
@@ -0,0 +1,94 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWatchdog is the error of a step failed by Watchdog, as it shows no sign of life for too long.
+var ErrWatchdog = errors.New("no sign of life from step")
+
+// watchdogLeaks counts steps failed by Watchdog, which are still running in background.
+var watchdogLeaks atomic.Int64
+
+// WatchdogLeaks returns the number of steps failed by Watchdog which are still running in background.
+// As goroutines cannot be killed, a failed step keeps running until it returns on its own.
+func WatchdogLeaks() int {
+	return int(watchdogLeaks.Load())
+}
+
+// Watchdog returns a copy of given chain, where every each step fails with ErrWatchdog when it shows no sign of
+// life for the quiet duration. Sign of life is either the completion of the step, or a call of Heartbeat from the step.
+// A step failed is told so by its context canceled with ErrWatchdog as the cause, and counted by WatchdogLeaks
+// until it returns.
+func Watchdog(t Task, quiet time.Duration) Task {
+	return Intercept(t, func(_ StepInfo, step StepCtx) StepCtx {
+		return func(ctx context.Context) error {
+			return watch(ctx, step, quiet)
+		}
+	})
+}
+
+// step states for watch
+const (
+	watchRunning int32 = iota
+	watchFinished
+	watchAbandoned
+)
+
+// watch runs the step in a separate goroutine, and abandons it when it shows no sign of life for quiet duration.
+func watch(ctx context.Context, step StepCtx, quiet time.Duration) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	beats := make(chan struct{}, 1)
+	ctx = withHeartbeat(ctx, func() {
+		select {
+		case beats <- struct{}{}:
+		default: // a beat is pending already
+		}
+	})
+
+	state, errChan := &atomic.Int32{}, make(chan error, 1)
+	go func() {
+		defer func() {
+			if !state.CompareAndSwap(watchRunning, watchFinished) {
+				watchdogLeaks.Add(-1)
+			}
+		}()
+		defer func() {
+			if p := recover(); p != nil {
+				if isStrict(ctx) {
+					panic(p)
+				}
+				errChan <- panicErr(p)
+			}
+		}()
+		errChan <- step(ctx)
+	}()
+
+	alarm := newAlarm(quiet)
+	defer alarm.stop()
+	for {
+		select {
+		case err := <-errChan:
+			return err
+		case <-beats:
+			alarm.reset()
+		case <-alarm.C:
+			if !alarm.fired() {
+				continue
+			}
+			watchdogLeaks.Add(1)
+			if !state.CompareAndSwap(watchRunning, watchAbandoned) { // finished in the meantime
+				watchdogLeaks.Add(-1)
+				return <-errChan
+			}
+			err := fmt.Errorf("%w for %v", ErrWatchdog, quiet)
+			cancel(err)
+			return err
+		}
+	}
+}
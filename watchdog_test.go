@@ -0,0 +1,55 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWatchdog_MustFailSilentStep_AndCountLeak(t *testing.T) {
+	// not parallel, as leaks are counted globally
+	release, returned := make(chan struct{}), make(chan error, 1)
+	tsk := Watchdog(Named("hang", WithCtx(func(ctx context.Context) error {
+		<-release
+		returned <- context.Cause(ctx)
+		return nil
+	})), time.Millisecond*50)
+
+	start := time.Now()
+	err := tsk.Run(context.Background())
+	assert.ErrorIs(t, err, ErrWatchdog)
+	assert.EqualError(t, err, "task 'hang' (step 0): no sign of life from step for 50ms")
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+	assert.Equal(t, 1, WatchdogLeaks())
+
+	close(release)
+	assert.ErrorIs(t, <-returned, ErrWatchdog)
+	assert.Eventually(t, func() bool { return WatchdogLeaks() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestWatchdog_MustKeepStepAlive_WhileBeating(t *testing.T) {
+	t.Parallel()
+	tsk := Watchdog(WithCtx(func(ctx context.Context) error {
+		for i := 0; i < 10; i++ {
+			time.Sleep(time.Millisecond * 20)
+			Heartbeat(ctx)
+		}
+		return nil
+	}), time.Millisecond*100)
+
+	assert.NoError(t, tsk.Run(context.Background()))
+}
+
+func TestWatchdog_MustPassResults_OfEveryStep(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("failure")
+	count := 0
+	tsk := Watchdog(WithNoErr(func() { count++ }).Then(With(func() error { return failure })), time.Second)
+
+	assert.ErrorIs(t, tsk.Run(context.Background()), failure)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 2, Count(tsk))
+	assert.EqualError(t, Watchdog(WithNoErr(func() { panic("panicked") }), time.Second).Run(context.Background()), "panicked")
+}